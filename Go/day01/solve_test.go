@@ -0,0 +1,10 @@
+package day01
+
+import (
+	"aoc/utils/testutil"
+	"testing"
+)
+
+func BenchmarkDay(b *testing.B) {
+	testutil.BenchmarkDay(b, 1, func(path string) error { _, err := parseInput(path); return err }, PartA, PartB)
+}
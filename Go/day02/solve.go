@@ -3,9 +3,7 @@ package day02
 import (
 	"aoc/utils"
 	"bufio"
-	"fmt"
 	"os"
-	"strings"
 )
 
 // "Data" from the game; maps colors to number of that item
@@ -17,26 +15,22 @@ type Game struct {
 	data []GameData
 }
 
-// Parses game data in form [num] [color1], [num] [color2], ...
-func parseGameData(gameData string) (GameData, error) {
-	scanner := bufio.NewScanner(strings.NewReader(gameData))
-	scanner.Split(utils.ScanDelimiterFunc(", "))
-
-	data := GameData{}
-	for scanner.Scan() {
-		var num int
-		var color string
-		_, err := fmt.Sscanf(scanner.Text(), "%d %s", &num, &color)
-		if err != nil {
-			return data, err
-		}
-
-		data[color] = num
-	}
+// A number of cubes of one color, in the form "[num] [color]"
+type cubeCount struct {
+	_     struct{} `pattern:"{Count} {Color}"`
+	Count int
+	Color string
+}
 
-	return data, nil
+// A line of input, with rounds separated by "; " and the colors in each by ", "
+type gameLine struct {
+	_      struct{} `pattern:"Game {ID}: {Rounds}"`
+	ID     int
+	Rounds [][]cubeCount `sep:"; " sep2:", "`
 }
 
+var gameParser = utils.MustLineParser[gameLine]()
+
 // Parse a series of games
 func parseInput(path string) ([]Game, error) {
 	f, err := os.Open(path)
@@ -48,29 +42,20 @@ func parseInput(path string) ([]Game, error) {
 	scanner := bufio.NewScanner(f)
 	scanner.Split(bufio.ScanLines)
 
-	var games []Game
-	for scanner.Scan() {
-
-		// Break into Game ID and game data
-		partScanner := bufio.NewScanner(strings.NewReader(scanner.Text()))
-		partScanner.Split(utils.ScanDelimiterFunc(": "))
-
-		id, err := utils.ReadItemFromScanner(partScanner, func(s string) (int, error) {
-			id := 0
-			_, err = fmt.Sscanf(s, "Game %d", &id)
-			return id, err
-		})
-		if err != nil {
-			return nil, err
-		}
+	lines, err := utils.ReadItems(scanner, gameParser.Parse, false)
+	if err != nil {
+		return nil, err
+	}
 
-		// Scan game data (separated by "; ")
-		game := Game{id, nil}
-		game.data, err = utils.ReadItemFromScanner(partScanner, func(s string) ([]GameData, error) {
-			return utils.ReadItems(utils.NewStringDelimiterScanner(s, "; "), parseGameData, false)
-		})
-		if err != nil {
-			return nil, err
+	var games []Game
+	for _, line := range lines {
+		game := Game{line.ID, nil}
+		for _, round := range line.Rounds {
+			data := GameData{}
+			for _, cubes := range round {
+				data[cubes.Color] = cubes.Count
+			}
+			game.data = append(game.data, data)
 		}
 
 		games = append(games, game)
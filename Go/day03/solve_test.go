@@ -0,0 +1,10 @@
+package day03
+
+import (
+	"aoc/utils/testutil"
+	"testing"
+)
+
+func BenchmarkDay(b *testing.B) {
+	testutil.BenchmarkDay(b, 3, func(path string) error { _, _, err := parseInput(path); return err }, PartA, PartB)
+}
@@ -0,0 +1,214 @@
+package day05
+
+import (
+	"aoc/utils"
+	"slices"
+	"sort"
+)
+
+// The largest seed value a composed map covers.  Seeds are assumed to be in [0, MaxSeed].
+const MaxSeed = 1 << 40
+
+// Every layer is extended out to this far in both directions, so that any value produced by an
+// earlier layer is covered.
+const layerExtent = 1 << 50
+
+// One piece of a layer, extended to cover the whole number line: values in Source are shifted by
+// Delta.  Mapped is false for pieces not covered by any RangeMap (which pass through unchanged).
+type layerSegment struct {
+	Source utils.Range
+	Delta  int
+	Mapped bool
+}
+
+// A range of seeds which all map to their location by adding the same delta.
+type ComposedSegment struct {
+	Seeds utils.Range
+	Delta int
+	// The layers (as indices into the map list) at which this range of seeds was not covered by
+	// any RangeMap, and so passed through unchanged
+	UnmappedLayers []int
+}
+
+// All the layers of the almanac composed into a single piecewise-linear map from seed to
+// location.  The segments are sorted and disjoint, and together cover every seed in [0, MaxSeed].
+type ComposedMap struct {
+	Segments []ComposedSegment
+	// The number of layers that were composed
+	Layers int
+}
+
+// Translates a single layer into a sorted, disjoint list of segments covering
+// [-layerExtent, layerExtent].  Where RangeMaps overlap, the first one listed wins, the same as
+// findLocation.
+func layerToSegments(layer []RangeMap) []layerSegment {
+	// Every RangeMap boundary is a point where the delta can change
+	breakpoints := []int{-layerExtent, layerExtent + 1}
+	for _, r := range layer {
+		breakpoints = append(breakpoints, r.SourceRange.Start, r.SourceRange.End+1)
+	}
+	slices.Sort(breakpoints)
+	breakpoints = slices.Compact(breakpoints)
+
+	var segments []layerSegment
+	for i := 0; i < len(breakpoints)-1; i++ {
+		piece := utils.Range{Start: breakpoints[i], End: breakpoints[i+1] - 1}
+		if piece.Start < -layerExtent || piece.End > layerExtent {
+			continue
+		}
+
+		// Nothing changes between breakpoints, so whichever RangeMap contains the start contains
+		// the whole piece
+		seg := layerSegment{Source: piece}
+		for _, r := range layer {
+			if r.SourceRange.ContainsNum(piece.Start) {
+				seg.Delta = r.GetDelta()
+				seg.Mapped = true
+				break
+			}
+		}
+
+		// Merge with the previous piece if it behaves identically
+		if n := len(segments); n > 0 && segments[n-1].Delta == seg.Delta && segments[n-1].Mapped == seg.Mapped {
+			segments[n-1].Source.End = piece.End
+		} else {
+			segments = append(segments, seg)
+		}
+	}
+
+	return segments
+}
+
+// Composes all of the layers into a single map from seed to location.
+func ComposeMaps(maps [][]RangeMap) ComposedMap {
+	cur := []ComposedSegment{{Seeds: utils.Range{Start: 0, End: MaxSeed}}}
+
+	for layerIdx, layer := range maps {
+		segments := layerToSegments(layer)
+
+		var next []ComposedSegment
+		for _, piece := range cur {
+			// Where this piece of seeds ends up before the current layer is applied.  This is a
+			// contiguous range since the whole piece is shifted by the same amount.
+			image := utils.Range{Start: piece.Seeds.Start + piece.Delta, End: piece.Seeds.End + piece.Delta}
+
+			// Find the first layer segment which could overlap, then split the piece everywhere
+			// the layer's delta changes
+			i := sort.Search(len(segments), func(i int) bool { return segments[i].Source.End >= image.Start })
+			for ; i < len(segments) && segments[i].Source.Start <= image.End; i++ {
+				seg := segments[i]
+				overlap := utils.Range{Start: max(image.Start, seg.Source.Start), End: min(image.End, seg.Source.End)}
+
+				unmapped := piece.UnmappedLayers
+				if !seg.Mapped {
+					unmapped = append(slices.Clone(unmapped), layerIdx)
+				}
+
+				next = append(next, ComposedSegment{
+					Seeds:          utils.Range{Start: overlap.Start - piece.Delta, End: overlap.End - piece.Delta},
+					Delta:          piece.Delta + seg.Delta,
+					UnmappedLayers: unmapped,
+				})
+			}
+		}
+
+		cur = next
+	}
+
+	return ComposedMap{Segments: cur, Layers: len(maps)}
+}
+
+// Gets the location for the given seed, or false if the seed is outside [0, MaxSeed].
+func (m *ComposedMap) Location(seed int) (int, bool) {
+	i := sort.Search(len(m.Segments), func(i int) bool { return m.Segments[i].Seeds.End >= seed })
+	if i == len(m.Segments) || !m.Segments[i].Seeds.ContainsNum(seed) {
+		return 0, false
+	}
+
+	return seed + m.Segments[i].Delta, true
+}
+
+// Gets every seed which maps to the given location, in increasing order.  If RangeMaps send
+// multiple sources to the same destination there may be more than one; if there are none, the
+// result is empty.
+func (m *ComposedMap) Seeds(location int) []int {
+	var seeds []int
+	for _, seg := range m.Segments {
+		seed := location - seg.Delta
+		if seg.Seeds.ContainsNum(seed) {
+			seeds = append(seeds, seed)
+		}
+	}
+
+	slices.Sort(seeds)
+	return seeds
+}
+
+// Gets the lowest location of any seed within the given ranges.  Returns false if there are no
+// seeds, or some of them are outside [0, MaxSeed].
+func (m *ComposedMap) MinLocation(seeds []utils.Range) (int, bool) {
+	minVal, found := 0, false
+	for _, r := range seeds {
+		if r.Start < 0 || r.End > MaxSeed {
+			return 0, false
+		}
+
+		for _, seg := range m.Segments {
+			if !seg.Seeds.Overlaps(r) {
+				continue
+			}
+
+			loc := max(r.Start, seg.Seeds.Start) + seg.Delta
+			if !found || loc < minVal {
+				minVal, found = loc, true
+			}
+		}
+	}
+
+	return minVal, found
+}
+
+// For each layer, gets the ranges of the given seeds which were not covered by any RangeMap at
+// that layer (and so passed through it unchanged).  Adjacent ranges are merged.
+func (m *ComposedMap) UnmappedSeeds(seeds []utils.Range) [][]utils.Range {
+	result := make([][]utils.Range, m.Layers)
+	for _, r := range seeds {
+		for _, seg := range m.Segments {
+			if !seg.Seeds.Overlaps(r) {
+				continue
+			}
+
+			overlap := utils.Range{Start: max(r.Start, seg.Seeds.Start), End: min(r.End, seg.Seeds.End)}
+			for _, layer := range seg.UnmappedLayers {
+				result[layer] = append(result[layer], overlap)
+			}
+		}
+	}
+
+	for i, ranges := range result {
+		slices.SortFunc(ranges, func(r1, r2 utils.Range) int { return r1.Start - r2.Start })
+
+		var merged []utils.Range
+		for _, r := range ranges {
+			if n := len(merged); n > 0 && merged[n-1].End+1 >= r.Start {
+				merged[n-1].End = max(merged[n-1].End, r.End)
+			} else {
+				merged = append(merged, r)
+			}
+		}
+		result[i] = merged
+	}
+
+	return result
+}
+
+// Reads the seed numbers and maps from the given almanac.
+func ReadAlmanac(path string) ([]int, [][]RangeMap, error) {
+	seeds, maps, _, err := parseInput(path)
+	return seeds, maps, err
+}
+
+// Gets the seed ranges described by the seed numbers, per the part 2 interpretation.
+func SeedRanges(seedValues []int) []utils.Range {
+	return seedsToRanges(seedValues)
+}
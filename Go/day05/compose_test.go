@@ -0,0 +1,127 @@
+package day05
+
+import (
+	"aoc/utils"
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestComposedMapOutsideSeeds(t *testing.T) {
+	maps := [][]RangeMap{
+		{{SourceRange: utils.NewRange(98, 2), Destination: 50}, {SourceRange: utils.NewRange(50, 48), Destination: 52}},
+		{{SourceRange: utils.NewRange(0, 10), Destination: 100}},
+	}
+	composed := ComposeMaps(maps)
+
+	for _, seed := range []int{0, 5, 49, 50, 97, 98, 99, 100, MaxSeed} {
+		if loc, ok := composed.Location(seed); !ok || loc != findLocation(seed, maps) {
+			t.Errorf("Location(%d) = %d, %t; want %d, true", seed, loc, ok, findLocation(seed, maps))
+		}
+	}
+	for _, seed := range []int{-1, MaxSeed + 1} {
+		if _, ok := composed.Location(seed); ok {
+			t.Errorf("Location(%d): expected a seed outside the composed map", seed)
+		}
+	}
+
+	if loc, ok := composed.MinLocation([]utils.Range{utils.NewRange(90, 20)}); !ok || loc != 50 {
+		t.Errorf("MinLocation = %d, %t; want 50, true", loc, ok)
+	}
+	if _, ok := composed.MinLocation([]utils.Range{utils.NewRange(MaxSeed, 2)}); ok {
+		t.Error("MinLocation: expected seeds outside the composed map")
+	}
+	if _, ok := composed.MinLocation(nil); ok {
+		t.Error("MinLocation: expected no location without seeds")
+	}
+}
+
+func TestComposeMapsSample(t *testing.T) {
+	seeds, maps, _, err := parseInput("../inputs/day05_sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	composed := ComposeMaps(maps)
+
+	if composed.Layers != len(maps) {
+		t.Errorf("got %d layers, want %d", composed.Layers, len(maps))
+	}
+
+	// The segments must be sorted and disjoint, and cover every seed
+	next := 0
+	for _, seg := range composed.Segments {
+		if seg.Seeds.Start != next || seg.Seeds.End < seg.Seeds.Start {
+			t.Fatalf("segment %v doesn't start at %d", seg.Seeds, next)
+		}
+		next = seg.Seeds.End + 1
+	}
+	if next != MaxSeed+1 {
+		t.Errorf("segments end at %d, want %d", next-1, MaxSeed)
+	}
+
+	// Every seed in and around the sample's maps must end up where the maps send it one at a time
+	for seed := 0; seed <= 120; seed++ {
+		if loc, ok := composed.Location(seed); !ok || loc != findLocation(seed, maps) {
+			t.Errorf("Location(%d) = %d, %t; want %d, true", seed, loc, ok, findLocation(seed, maps))
+		}
+	}
+
+	// The sample's answers
+	minLoc := math.MaxInt
+	for _, seed := range seeds {
+		loc, _ := composed.Location(seed)
+		minLoc = min(minLoc, loc)
+	}
+	if minLoc != 35 {
+		t.Errorf("lowest part 1 location is %d, want 35", minLoc)
+	}
+	if loc, ok := composed.MinLocation(seedsToRanges(seeds)); !ok || loc != 46 {
+		t.Errorf("MinLocation = %d, %t; want 46, true", loc, ok)
+	}
+}
+
+func TestComposeMapsOverlapsAndGaps(t *testing.T) {
+	maps := [][]RangeMap{
+		// Overlapping sources; the first listed wins
+		{{SourceRange: utils.NewRange(10, 10), Destination: 100}, {SourceRange: utils.NewRange(15, 10), Destination: 200}},
+		// Maps part of the first layer's output, leaving the rest to pass through
+		{{SourceRange: utils.NewRange(105, 3), Destination: 0}},
+	}
+	composed := ComposeMaps(maps)
+
+	for seed := 0; seed <= 30; seed++ {
+		if loc, ok := composed.Location(seed); !ok || loc != findLocation(seed, maps) {
+			t.Errorf("Location(%d) = %d, %t; want %d, true", seed, loc, ok, findLocation(seed, maps))
+		}
+	}
+
+	// Seeds 15-17 are sent to 105-107, then to 0-2
+	if loc, ok := composed.MinLocation([]utils.Range{utils.NewRange(12, 10)}); !ok || loc != 0 {
+		t.Errorf("MinLocation = %d, %t; want 0, true", loc, ok)
+	}
+	// 1 passes through both layers, and 106 passes through the first then is mapped by the second
+	if got, want := composed.Seeds(1), []int{1, 16, 106}; !slices.Equal(got, want) {
+		t.Errorf("Seeds(1) = %v, want %v", got, want)
+	}
+}
+
+func TestComposedMapNegativeLocations(t *testing.T) {
+	maps := [][]RangeMap{{{SourceRange: utils.NewRange(0, 10), Destination: -10}}}
+	composed := ComposeMaps(maps)
+
+	if loc, ok := composed.Location(9); !ok || loc != -1 {
+		t.Errorf("Location(9) = %d, %t; want -1, true", loc, ok)
+	}
+	for _, test := range []struct {
+		seeds utils.Range
+		want  int
+	}{
+		{utils.NewRange(9, 1), -1},
+		{utils.NewRange(5, 20), -5},
+		{utils.NewRange(10, 5), 10},
+	} {
+		if loc, ok := composed.MinLocation([]utils.Range{test.seeds}); !ok || loc != test.want {
+			t.Errorf("MinLocation(%v) = %d, %t; want %d, true", test.seeds, loc, ok, test.want)
+		}
+	}
+}
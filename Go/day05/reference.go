@@ -0,0 +1,59 @@
+//go:build reference
+
+package day05
+
+import (
+	"aoc/utils"
+	"math"
+)
+
+// These map one seed at a time, checking every range of every map, where the real solution
+// splits whole ranges of seeds as it goes.
+
+// Maps a value through a single map by checking every range in turn; values no range covers pass
+// through unchanged.
+func referenceMapValue(value int, mapping []RangeMap) int {
+	for _, r := range mapping {
+		if value >= r.SourceRange.Start && value < r.SourceRange.Start+r.SourceRange.Length() {
+			return r.Destination + (value - r.SourceRange.Start)
+		}
+	}
+
+	return value
+}
+
+// Maps a seed through every map to its location
+func referenceLocation(seed int, maps [][]RangeMap) int {
+	for _, mapping := range maps {
+		seed = referenceMapValue(seed, mapping)
+	}
+
+	return seed
+}
+
+func ReferencePartA(path string) int {
+	seeds, maps, _, err := parseInput(path)
+	utils.CheckError(err)
+
+	minVal := math.MaxInt
+	for _, seed := range seeds {
+		minVal = min(minVal, referenceLocation(seed, maps))
+	}
+
+	return minVal
+}
+
+// Maps every single seed in every range, rather than splitting ranges.
+func ReferencePartB(path string) int {
+	seeds, maps, _, err := parseInput(path)
+	utils.CheckError(err)
+
+	minVal := math.MaxInt
+	for i := 0; i+1 < len(seeds); i += 2 {
+		for seed := seeds[i]; seed < seeds[i]+seeds[i+1]; seed++ {
+			minVal = min(minVal, referenceLocation(seed, maps))
+		}
+	}
+
+	return minVal
+}
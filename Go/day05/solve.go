@@ -41,12 +41,12 @@ func parseRangeMap(mapData string) (RangeMap, error) {
 	return RangeMap{SourceRange: utils.NewRange(numbers[1], numbers[2]), Destination: numbers[0]}, nil
 }
 
-// Parses the starting seed values and series of map values
-func parseInput(path string) ([]int, [][]RangeMap, error) {
+// Parses the starting seed values, series of map values, and the title of each map
+func parseInput(path string) ([]int, [][]RangeMap, []string, error) {
 	// Open file
 	f, err := os.Open(path)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 	defer f.Close()
 
@@ -59,33 +59,35 @@ func parseInput(path string) ([]int, [][]RangeMap, error) {
 		return utils.ReadItems(utils.NewStringDelimiterScanner(s[len("seeds: "):], " "), strconv.Atoi, false)
 	})
 	if err != nil {
-		return seeds, nil, err
+		return seeds, nil, nil, err
 	}
 
 	// The rest of the double-newline separated groups are maps.  We record them in a slice,
 	// since we know the maps apply sequentially to a given value/range
 	var maps [][]RangeMap
+	var titles []string
 	for scanner.Scan() {
 		// Iterate over each line within a group
 		groupIt := bufio.NewScanner(strings.NewReader(scanner.Text()))
 		groupIt.Split(bufio.ScanLines)
 
-		// Ignore title
-		_, err = utils.ReadStringFromScanner(groupIt)
+		// Record title, so the order of maps can be validated
+		title, err := utils.ReadStringFromScanner(groupIt)
 		if err != nil {
-			return seeds, maps, err
+			return seeds, maps, titles, err
 		}
 
 		// Parse range maps
 		parsedMap, err := utils.ReadItems(groupIt, parseRangeMap, false)
 		if err != nil {
-			return seeds, maps, err
+			return seeds, maps, titles, err
 		}
 
 		maps = append(maps, parsedMap)
+		titles = append(titles, title)
 	}
 
-	return seeds, maps, nil
+	return seeds, maps, titles, nil
 }
 
 // Maps a series of seed numbers as read in by parseInput to a series of ranges defined by
@@ -211,26 +213,38 @@ func findLocationRanges(seedRange []utils.Range, mappings [][]RangeMap) []utils.
 }
 
 func PartA(path string) int {
-	seeds, maps, err := parseInput(path)
+	seeds, maps, _, err := parseInput(path)
 	utils.CheckError(err)
 
-	// Find the minimum location value
+	// The composed map finds each location with a single lookup; the rare seed it doesn't cover is
+	// taken through the maps one at a time instead
+	composed := ComposeMaps(maps)
 	minVal := math.MaxInt
 	for _, seed := range seeds {
-		minVal = min(minVal, findLocation(seed, maps))
+		loc, ok := composed.Location(seed)
+		if !ok {
+			loc = findLocation(seed, maps)
+		}
+		minVal = min(minVal, loc)
 	}
 
 	return minVal
 }
 
 func PartB(path string) int {
-	seedData, maps, err := parseInput(path)
+	seedData, maps, _, err := parseInput(path)
 	utils.CheckError(err)
 
 	// Translate the seed numbers into ranges per the part 2 definition
 	seeds := seedsToRanges(seedData)
 
-	// Apply the maps to the seed ranges
+	// The composed map knows the location of every seed, so can go straight to the lowest one
+	composed := ComposeMaps(maps)
+	if minVal, ok := composed.MinLocation(seeds); ok {
+		return minVal
+	}
+
+	// Otherwise, apply the maps to the seed ranges
 	locations := findLocationRanges(seeds, maps)
 
 	// Find the minimum location value
@@ -0,0 +1,10 @@
+package day05
+
+import (
+	"aoc/utils/testutil"
+	"testing"
+)
+
+func BenchmarkDay(b *testing.B) {
+	testutil.BenchmarkDay(b, 5, func(path string) error { _, _, _, err := parseInput(path); return err }, PartA, PartB)
+}
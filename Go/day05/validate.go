@@ -0,0 +1,176 @@
+package day05
+
+import (
+	"aoc/utils"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// The order the maps are expected to appear in, given by the category each one maps from
+var expectedCategories = []string{"seed", "soil", "fertilizer", "water", "light", "temperature", "humidity", "location"}
+
+// A problem found with an almanac.
+type Problem struct {
+	// The index of the map (layer) the problem was found in
+	Layer int
+	// The title of the map the problem was found in
+	Title string
+	// A description of the problem
+	Message string
+	// True if the problem is merely suspicious; false if the almanac is definitely inconsistent
+	Warning bool
+}
+
+func (p Problem) String() string {
+	severity := "error"
+	if p.Warning {
+		severity = "warning"
+	}
+
+	if p.Title == "" {
+		return fmt.Sprintf("%s: %s", severity, p.Message)
+	}
+	return fmt.Sprintf("%s: map %d (%s): %s", severity, p.Layer, p.Title, p.Message)
+}
+
+// Parses a title such as "seed-to-soil map:" into its source and destination categories.
+func parseTitle(title string) (string, string, bool) {
+	name, ok := strings.CutSuffix(title, " map:")
+	if !ok {
+		return "", "", false
+	}
+
+	return strings.Cut(name, "-to-")
+}
+
+// Formats a RangeMap the way it appears in the almanac, for use in messages
+func formatRangeMap(r RangeMap) string {
+	return fmt.Sprintf("\"%d %d %d\"", r.Destination, r.SourceRange.Start, r.SourceRange.Length())
+}
+
+// Gets the portions of r which aren't covered by any of the given ranges.
+func subtractRanges(r utils.Range, ranges []utils.Range) []utils.Range {
+	remaining := []utils.Range{r}
+	for _, sub := range ranges {
+		var next []utils.Range
+		for _, cur := range remaining {
+			if !cur.Overlaps(sub) {
+				next = append(next, cur)
+				continue
+			}
+
+			if cur.Start < sub.Start {
+				next = append(next, utils.Range{Start: cur.Start, End: sub.Start - 1})
+			}
+			if cur.End > sub.End {
+				next = append(next, utils.Range{Start: sub.End + 1, End: cur.End})
+			}
+		}
+		remaining = next
+	}
+
+	return remaining
+}
+
+// Checks a single layer for RangeMaps whose sources overlap (which evaluateMap would apply both
+// shifts to), and for values which more than one source ends up at.
+func validateLayer(layerIdx int, title string, layer []RangeMap) []Problem {
+	var problems []Problem
+	report := func(warning bool, format string, args ...any) {
+		problems = append(problems, Problem{Layer: layerIdx, Title: title, Message: fmt.Sprintf(format, args...), Warning: warning})
+	}
+
+	var sources []utils.Range
+	for i, r1 := range layer {
+		if r1.SourceRange.Length() <= 0 {
+			report(false, "range %s has no length", formatRangeMap(r1))
+		}
+		sources = append(sources, r1.SourceRange)
+
+		for _, r2 := range layer[:i] {
+			if r1.SourceRange.Overlaps(r2.SourceRange) {
+				report(false, "ranges %s and %s overlap on sources %d-%d", formatRangeMap(r2), formatRangeMap(r1),
+					max(r1.SourceRange.Start, r2.SourceRange.Start), min(r1.SourceRange.End, r2.SourceRange.End))
+			}
+
+			dst1 := utils.NewRange(r1.Destination, r1.SourceRange.Length())
+			dst2 := utils.NewRange(r2.Destination, r2.SourceRange.Length())
+			if dst1.Overlaps(dst2) {
+				report(false, "ranges %s and %s both map to destinations %d-%d", formatRangeMap(r2), formatRangeMap(r1),
+					max(dst1.Start, dst2.Start), min(dst1.End, dst2.End))
+			}
+		}
+	}
+
+	// Values not covered by any source pass through unchanged; so if a RangeMap sends something to
+	// one of those values, two sources collide there
+	for _, r := range layer {
+		for _, collision := range subtractRanges(utils.NewRange(r.Destination, r.SourceRange.Length()), sources) {
+			report(false, "range %s maps to destinations %d-%d, which unmapped sources also pass through to", formatRangeMap(r), collision.Start, collision.End)
+		}
+	}
+
+	return problems
+}
+
+// Checks the almanac for overlapping source ranges and destination collisions within each layer,
+// and for layers which aren't in seed -> soil -> ... -> location order.
+func validateAlmanac(maps [][]RangeMap, titles []string) []Problem {
+	var problems []Problem
+
+	for i, title := range titles {
+		src, dst, ok := parseTitle(title)
+		if !ok {
+			problems = append(problems, Problem{Layer: i, Title: title, Message: "title is not in the form \"x-to-y map:\"", Warning: true})
+			continue
+		}
+
+		// Each map should pick up where the last left off, following the standard order
+		if i+1 < len(expectedCategories) && (src != expectedCategories[i] || dst != expectedCategories[i+1]) {
+			problems = append(problems, Problem{Layer: i, Title: title,
+				Message: fmt.Sprintf("expected %s-to-%s map", expectedCategories[i], expectedCategories[i+1]), Warning: true})
+		} else if i > 0 {
+			if prevSrc, prevDst, ok := parseTitle(titles[i-1]); ok && prevDst != src {
+				problems = append(problems, Problem{Layer: i, Title: title,
+					Message: fmt.Sprintf("maps from %s, but the previous map (%s-to-%s) maps to %s", src, prevSrc, prevDst, prevDst), Warning: true})
+			}
+		}
+	}
+
+	if len(titles) != len(expectedCategories)-1 {
+		// Report against the last map, if there is one
+		problem := Problem{Message: fmt.Sprintf("expected %d maps ending at location, found %d", len(expectedCategories)-1, len(titles)), Warning: true}
+		if len(titles) > 0 {
+			problem.Layer, problem.Title = len(titles)-1, titles[len(titles)-1]
+		}
+		problems = append(problems, problem)
+	}
+
+	for i, layer := range maps {
+		problems = append(problems, validateLayer(i, titles[i], layer)...)
+	}
+
+	// Report errors before warnings, and otherwise in order of layer
+	slices.SortStableFunc(problems, func(p1, p2 Problem) int {
+		if p1.Warning != p2.Warning {
+			if p1.Warning {
+				return 1
+			}
+			return -1
+		}
+		return p1.Layer - p2.Layer
+	})
+
+	return problems
+}
+
+// Reads the almanac at the given path and checks it for problems; see validateAlmanac.
+func ValidateAlmanac(path string) ([]Problem, error) {
+	_, maps, titles, err := parseInput(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return validateAlmanac(maps, titles), nil
+}
@@ -0,0 +1,126 @@
+package day05
+
+import (
+	"aoc/utils"
+	"testing"
+)
+
+func TestValidateAlmanacWithoutMaps(t *testing.T) {
+	problems := validateAlmanac(nil, nil)
+	if len(problems) != 1 || problems[0].Title != "" || !problems[0].Warning {
+		t.Fatalf("expected a single missing maps warning, got %v", problems)
+	}
+	if got, want := problems[0].String(), "warning: expected 7 maps ending at location, found 0"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+// Gets the titles of the maps in the standard order
+func standardTitles() []string {
+	var titles []string
+	for i := 0; i+1 < len(expectedCategories); i++ {
+		titles = append(titles, expectedCategories[i]+"-to-"+expectedCategories[i+1]+" map:")
+	}
+
+	return titles
+}
+
+// Checks that the problems include one in the given layer with the given message and severity
+func checkHasProblem(t *testing.T, problems []Problem, layer int, message string, warning bool) {
+	t.Helper()
+
+	for _, p := range problems {
+		if p.Layer == layer && p.Message == message && p.Warning == warning {
+			return
+		}
+	}
+	t.Errorf("expected a problem in layer %d with message %q (warning == %t), got %v", layer, message, warning, problems)
+}
+
+func TestValidateAlmanacSample(t *testing.T) {
+	problems, err := ValidateAlmanac("../inputs/day05_sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 0 {
+		t.Errorf("expected no problems, got %v", problems)
+	}
+}
+
+func TestValidateAlmanacOverlappingSources(t *testing.T) {
+	maps := make([][]RangeMap, 7)
+	maps[2] = []RangeMap{
+		{SourceRange: utils.NewRange(98, 2), Destination: 50},
+		{SourceRange: utils.NewRange(99, 5), Destination: 60},
+	}
+
+	problems := validateAlmanac(maps, standardTitles())
+	checkHasProblem(t, problems, 2, "ranges \"50 98 2\" and \"60 99 5\" overlap on sources 99-99", false)
+	if got, want := problems[0].String(), "error: map 2 (fertilizer-to-water map:): ranges \"50 98 2\" and \"60 99 5\" overlap on sources 99-99"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestValidateAlmanacCollidingDestinations(t *testing.T) {
+	maps := make([][]RangeMap, 7)
+	maps[0] = []RangeMap{
+		{SourceRange: utils.NewRange(0, 5), Destination: 10},
+		{SourceRange: utils.NewRange(5, 5), Destination: 12},
+	}
+
+	problems := validateAlmanac(maps, standardTitles())
+	checkHasProblem(t, problems, 0, "ranges \"10 0 5\" and \"12 5 5\" both map to destinations 12-14", false)
+
+	// Nothing maps 10-16, so those seeds pass through to where the maps also send 0-9
+	checkHasProblem(t, problems, 0, "range \"10 0 5\" maps to destinations 10-14, which unmapped sources also pass through to", false)
+	checkHasProblem(t, problems, 0, "range \"12 5 5\" maps to destinations 12-16, which unmapped sources also pass through to", false)
+
+	if len(problems) != 3 {
+		t.Errorf("expected 3 problems, got %v", problems)
+	}
+
+	// Swapping ranges around is fine, since nothing collides
+	maps[0] = []RangeMap{
+		{SourceRange: utils.NewRange(0, 5), Destination: 5},
+		{SourceRange: utils.NewRange(5, 5), Destination: 0},
+	}
+	if problems := validateAlmanac(maps, standardTitles()); len(problems) != 0 {
+		t.Errorf("expected no problems for swapped ranges, got %v", problems)
+	}
+}
+
+func TestValidateAlmanacOrder(t *testing.T) {
+	titles := standardTitles()
+	titles[1], titles[2] = titles[2], titles[1]
+	problems := validateAlmanac(make([][]RangeMap, 7), titles)
+	checkHasProblem(t, problems, 1, "expected soil-to-fertilizer map", true)
+	checkHasProblem(t, problems, 2, "expected fertilizer-to-water map", true)
+
+	// A map in the right place, after one which isn't
+	titles = standardTitles()
+	titles[0] = "seed-to-dirt map:"
+	problems = validateAlmanac(make([][]RangeMap, 7), titles)
+	checkHasProblem(t, problems, 0, "expected seed-to-soil map", true)
+	checkHasProblem(t, problems, 1, "maps from soil, but the previous map (seed-to-dirt) maps to dirt", true)
+
+	titles = standardTitles()
+	titles[3] = "water to light"
+	problems = validateAlmanac(make([][]RangeMap, 7), titles)
+	checkHasProblem(t, problems, 3, "title is not in the form \"x-to-y map:\"", true)
+
+	titles = append(standardTitles(), "location-to-planet map:")
+	problems = validateAlmanac(make([][]RangeMap, 8), titles)
+	checkHasProblem(t, problems, 7, "expected 7 maps ending at location, found 8", true)
+}
+
+func TestValidateAlmanacErrorsFirst(t *testing.T) {
+	titles := standardTitles()
+	titles[0] = "seed-to-dirt map:"
+	maps := make([][]RangeMap, 7)
+	maps[5] = []RangeMap{{SourceRange: utils.Range{Start: 10, End: 9}, Destination: 0}}
+
+	problems := validateAlmanac(maps, titles)
+	if len(problems) < 2 || problems[0].Warning || problems[0].Layer != 5 || !problems[len(problems)-1].Warning {
+		t.Errorf("expected the error in layer 5 before any warnings, got %v", problems)
+	}
+}
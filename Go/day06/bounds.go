@@ -0,0 +1,138 @@
+package day06
+
+import (
+	"aoc/utils"
+	"fmt"
+)
+
+// Gets how far the boat goes if the button is held for the given time.
+func getDistance(race Race, holdTime int) int {
+	return holdTime * (race.Time - holdTime)
+}
+
+// Finds the range of hold times which beat the record, or returns false if none do.
+//
+// Holding for h gives a distance of h * (T - h), so we win when h^2 - T*h + D < 0; that is, for
+// h strictly between the roots (T +/- sqrt(T^2 - 4D)) / 2.  We estimate the lower root with an
+// integer square root, then nudge it to the exact first winning time.  Everything stays in
+// integers, so there are no floating-point errors when the root is itself an integer (where the
+// distance only ties the record).  The winning times are symmetric about T/2, so the last winning
+// time is T minus the first.
+func getWinningHoldTimes(race Race) (utils.Range, bool, error) {
+	if _, ok := utils.MulChecked(int64(race.Time), int64(race.Time)); !ok || race.Time < 0 {
+		return utils.Range{}, false, fmt.Errorf("race time %d is out of range", race.Time)
+	}
+
+	// The distance is greatest in the middle, so if that doesn't win, nothing does
+	mid := race.Time / 2
+	if getDistance(race, mid) <= race.RecordDistance {
+		return utils.Range{}, false, nil
+	}
+
+	// Since the middle wins, the discriminant is positive
+	disc := race.Time*race.Time - 4*race.RecordDistance
+	first := max((race.Time-utils.Isqrt(disc))/2, 0)
+	for first > 0 && getDistance(race, first-1) > race.RecordDistance {
+		first--
+	}
+	for getDistance(race, first) <= race.RecordDistance {
+		first++
+	}
+
+	return utils.Range{Start: first, End: race.Time - first}, true, nil
+}
+
+// Finds the range of winning hold times by trying every one.
+func getWinningHoldTimesBruteForce(race Race) (utils.Range, bool) {
+	result := utils.Range{Start: -1}
+	for holdTime := 0; holdTime <= race.Time; holdTime++ {
+		if getDistance(race, holdTime) > race.RecordDistance {
+			if result.Start == -1 {
+				result.Start = holdTime
+			}
+			result.End = holdTime
+		}
+	}
+
+	return result, result.Start != -1
+}
+
+// Gets the number of hold times which beat the record.
+func getWaysToWinClosedForm(race Race) (int, error) {
+	bounds, ok, err := getWinningHoldTimes(race)
+	if err != nil || !ok {
+		return 0, err
+	}
+
+	return bounds.Length(), nil
+}
+
+// Reads every race in the input, followed by the single race from reading the input as one long
+// race.
+func readAllRaces(path string) ([]Race, error) {
+	races, err := parseInput(path)
+	if err != nil {
+		return nil, err
+	}
+
+	race, err := parseInput2(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(races, race), nil
+}
+
+// The hold times which win a race
+type RaceBounds struct {
+	// The first and last winning hold times; only meaningful if Winnable is set
+	Times utils.Range
+	// Whether any hold time beats the record
+	Winnable bool
+}
+
+// Gets the first and last winning hold times for each race in the input, followed by the single
+// race from reading the input as one long race.
+func GetWinningHoldTimes(path string) ([]RaceBounds, error) {
+	races, err := readAllRaces(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []RaceBounds
+	for _, r := range races {
+		bounds, ok, err := getWinningHoldTimes(r)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, RaceBounds{Times: bounds, Winnable: ok})
+	}
+
+	return result, nil
+}
+
+// Checks the closed-form bounds against brute force for every race in the input (including the
+// single long race), returning an error describing the first disagreement.  The long race can take
+// a while to brute force.
+func CheckWinningHoldTimes(path string) error {
+	races, err := readAllRaces(path)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range races {
+		bounds, ok, err := getWinningHoldTimes(r)
+		if err != nil {
+			return err
+		}
+
+		expected, expectedOk := getWinningHoldTimesBruteForce(r)
+		if ok != expectedOk || (ok && bounds != expected) {
+			return fmt.Errorf("race (time %d, record %d): closed form gave %v (winnable: %t), brute force gave %v (winnable: %t)",
+				r.Time, r.RecordDistance, bounds, ok, expected, expectedOk)
+		}
+	}
+
+	return nil
+}
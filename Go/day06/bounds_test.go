@@ -0,0 +1,78 @@
+package day06
+
+import (
+	"aoc/utils"
+	"testing"
+)
+
+func TestWinningHoldTimes(t *testing.T) {
+	tests := []struct {
+		race Race
+		want RaceBounds
+	}{
+		{Race{7, 9}, RaceBounds{utils.Range{Start: 2, End: 5}, true}},
+		{Race{15, 40}, RaceBounds{utils.Range{Start: 4, End: 11}, true}},
+		// The roots are exactly 10 and 20, which only tie the record
+		{Race{30, 200}, RaceBounds{utils.Range{Start: 11, End: 19}, true}},
+		{Race{71530, 940200}, RaceBounds{utils.Range{Start: 14, End: 71516}, true}},
+		{Race{2, 0}, RaceBounds{utils.Range{Start: 1, End: 1}, true}},
+		{Race{5, -1}, RaceBounds{utils.Range{Start: 0, End: 5}, true}},
+		{Race{4, 4}, RaceBounds{}},
+		{Race{1, 0}, RaceBounds{}},
+		{Race{0, 0}, RaceBounds{}},
+	}
+
+	for _, test := range tests {
+		bounds, ok, err := getWinningHoldTimes(test.race)
+		if err != nil {
+			t.Errorf("%+v: %v", test.race, err)
+		} else if ok != test.want.Winnable || (ok && bounds != test.want.Times) {
+			t.Errorf("%+v: got %v (winnable: %t), want %v (winnable: %t)", test.race, bounds, ok, test.want.Times, test.want.Winnable)
+		}
+	}
+
+	if _, _, err := getWinningHoldTimes(Race{-1, 0}); err == nil {
+		t.Error("expected an error for a negative race time")
+	}
+	if _, _, err := getWinningHoldTimes(Race{1 << 32, 0}); err == nil {
+		t.Error("expected an error for a race time whose square overflows")
+	}
+}
+
+func TestWinningHoldTimesMatchBruteForce(t *testing.T) {
+	for time := 0; time <= 60; time++ {
+		for record := -1; record <= time*time/4+1; record++ {
+			race := Race{time, record}
+			bounds, ok, err := getWinningHoldTimes(race)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expected, expectedOk := getWinningHoldTimesBruteForce(race)
+			if ok != expectedOk || (ok && bounds != expected) {
+				t.Fatalf("%+v: closed form gave %v (winnable: %t), brute force gave %v (winnable: %t)", race, bounds, ok, expected, expectedOk)
+			}
+		}
+	}
+}
+
+func TestCheckWinningHoldTimes(t *testing.T) {
+	const path = "../inputs/day06_sample.txt"
+	if err := CheckWinningHoldTimes(path); err != nil {
+		t.Error(err)
+	}
+
+	bounds, err := GetWinningHoldTimes(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []int{4, 8, 9, 71503}
+	if len(bounds) != len(want) {
+		t.Fatalf("got %d races, want %d", len(bounds), len(want))
+	}
+	for i, b := range bounds {
+		if !b.Winnable || b.Times.Length() != want[i] {
+			t.Errorf("race %d: got %+v, want %d winning hold times", i, b, want[i])
+		}
+	}
+}
@@ -0,0 +1,37 @@
+//go:build reference
+
+package day06
+
+import "aoc/utils"
+
+// These try every hold time in each race, where the real solution solves a quadratic for the first
+// and last winning ones.
+
+// Counts the hold times which beat the record by trying every one.
+func referenceWaysToWin(race Race) int {
+	bounds, ok := getWinningHoldTimesBruteForce(race)
+	if !ok {
+		return 0
+	}
+
+	return bounds.Length()
+}
+
+func ReferencePartA(path string) int {
+	races, err := parseInput(path)
+	utils.CheckError(err)
+
+	prod := 1
+	for _, race := range races {
+		prod *= referenceWaysToWin(race)
+	}
+
+	return prod
+}
+
+func ReferencePartB(path string) int {
+	race, err := parseInput2(path)
+	utils.CheckError(err)
+
+	return referenceWaysToWin(race)
+}
@@ -102,30 +102,16 @@ func parseInput2(path string) (Race, error) {
 	return Race{Time: time, RecordDistance: dist}, nil
 }
 
-func getWaysToWin(race Race) int {
-	waysToWin := 0
-	for timeIdx := 0; timeIdx < race.Time; timeIdx++ {
-		// Figure out how far we would get if we started movement at this time unit.
-		// We use this closed-form solution so we don't have to simulate it (we want O(n) rather
-		// than O(n^2) or worse)
-		dist := timeIdx * (race.Time - timeIdx)
-
-		// If it's a winning distance, count it
-		if dist > race.RecordDistance {
-			waysToWin++
-		}
-	}
-
-	return waysToWin
-}
-
 func PartA(path string) int {
 	races, err := parseInput(path)
 	utils.CheckError(err)
 
 	prod := 1
 	for _, race := range races {
-		prod *= getWaysToWin(race)
+		ways, err := getWaysToWinClosedForm(race)
+		utils.CheckError(err)
+
+		prod *= ways
 	}
 
 	return prod
@@ -135,5 +121,8 @@ func PartB(path string) int {
 	race, err := parseInput2(path)
 	utils.CheckError(err)
 
-	return getWaysToWin(race)
+	ways, err := getWaysToWinClosedForm(race)
+	utils.CheckError(err)
+
+	return ways
 }
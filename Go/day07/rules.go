@@ -0,0 +1,316 @@
+package day07
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// A single card in a hand.  Suit is 0 if the rules don't use suits.
+type Card struct {
+	Rank byte
+	Suit byte
+}
+
+// A type of hand, such as "full house".  Match reports whether the (fully resolved, wildcard-free)
+// cards qualify for the category.
+type Category struct {
+	Name  string
+	Match func(cards []Card, rules *Rules) bool
+}
+
+// The configuration of a card game.
+type Rules struct {
+	// Card ranks from weakest to strongest.  This order is used both for tie-breaks and to decide
+	// what counts as a straight.
+	Order string
+	// Ranks which can stand in for any card when determining a hand's category.  For tie-breaks
+	// they are ranked by their place in Order as normal.
+	Wildcards string
+	// If set, the suits cards may have; each card is then written as a rank followed by a suit
+	// (eg. "AhKh").  If empty, each card is a single rank.
+	Suits string
+	// The number of cards in a hand
+	HandSize int
+	// The categories a hand can fall into, from weakest to strongest.  A hand's category is the
+	// strongest one it matches.
+	Categories []Category
+}
+
+// Gets the sizes of the groups of equal ranks in the hand, largest first.
+func groupSizes(cards []Card) []int {
+	hist := map[byte]int{}
+	for _, c := range cards {
+		hist[c.Rank]++
+	}
+
+	var sizes []int
+	for _, v := range hist {
+		sizes = append(sizes, v)
+	}
+	slices.Sort(sizes)
+	slices.Reverse(sizes)
+
+	return sizes
+}
+
+// Creates a category matching hands which contain disjoint groups of equal ranks of (at least)
+// the given sizes.  For example, Groups("Full House", 3, 2) matches a hand with 3 of one rank and
+// 2 of another.
+func Groups(name string, sizes ...int) Category {
+	return Category{
+		Name: name,
+		Match: func(cards []Card, rules *Rules) bool {
+			// The largest groups in the hand must cover the requested groups, largest to smallest
+			actual := groupSizes(cards)
+			if len(actual) < len(sizes) {
+				return false
+			}
+			for i, size := range sizes {
+				if actual[i] < size {
+					return false
+				}
+			}
+
+			return true
+		},
+	}
+}
+
+// Returns whether every card in the hand has a distinct rank, and the ranks are consecutive in the
+// rule's Order.
+func isStraight(cards []Card, rules *Rules) bool {
+	var indices []int
+	for _, c := range cards {
+		indices = append(indices, strings.IndexByte(rules.Order, c.Rank))
+	}
+	slices.Sort(indices)
+
+	for i := 1; i < len(indices); i++ {
+		if indices[i] != indices[i-1]+1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns whether every card in the hand has the same suit.  Never true when the rules have no
+// suits.
+func isFlush(cards []Card, rules *Rules) bool {
+	if rules.Suits == "" {
+		return false
+	}
+
+	for _, c := range cards {
+		if c.Suit != cards[0].Suit {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Categories for hands with repeated ranks
+var (
+	HighCard     = Groups("High Card")
+	OnePair      = Groups("One Pair", 2)
+	TwoPair      = Groups("Two Pair", 2, 2)
+	ThreeOfAKind = Groups("Three of a Kind", 3)
+	FullHouse    = Groups("Full House", 3, 2)
+	FourOfAKind  = Groups("Four of a Kind", 4)
+	FiveOfAKind  = Groups("Five of a Kind", 5)
+)
+
+// Categories for hands with consecutive ranks and/or matching suits
+var (
+	Straight      = Category{Name: "Straight", Match: isStraight}
+	Flush         = Category{Name: "Flush", Match: isFlush}
+	StraightFlush = Category{Name: "Straight Flush", Match: func(cards []Card, rules *Rules) bool {
+		return isStraight(cards, rules) && isFlush(cards, rules)
+	}}
+)
+
+// The categories of Camel Cards, weakest to strongest
+var StandardCategories = []Category{HighCard, OnePair, TwoPair, ThreeOfAKind, FullHouse, FourOfAKind, FiveOfAKind}
+
+// Translates the hand's text into cards, checking them against the rules.
+func (rules *Rules) parseCards(hand []byte) ([]Card, error) {
+	width := 1
+	if rules.Suits != "" {
+		width = 2
+	}
+
+	if len(hand) != rules.HandSize*width {
+		return nil, fmt.Errorf("hand %q should have %d cards", hand, rules.HandSize)
+	}
+
+	var cards []Card
+	for i := 0; i < len(hand); i += width {
+		card := Card{Rank: hand[i]}
+		if strings.IndexByte(rules.Order, card.Rank) == -1 {
+			return nil, fmt.Errorf("hand %q has unknown rank %q", hand, card.Rank)
+		}
+
+		if width == 2 {
+			card.Suit = hand[i+1]
+			if strings.IndexByte(rules.Suits, card.Suit) == -1 {
+				return nil, fmt.Errorf("hand %q has unknown suit %q", hand, card.Suit)
+			}
+		}
+
+		cards = append(cards, card)
+	}
+
+	return cards, nil
+}
+
+// Gets the index (in Categories) of the strongest category the cards match, or -1 if none do.
+func (rules *Rules) classify(cards []Card) int {
+	for i := len(rules.Categories) - 1; i >= 0; i-- {
+		if rules.Categories[i].Match(cards, rules) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Gets every card a wildcard could stand in for.
+func (rules *Rules) candidateCards() []Card {
+	suits := []byte(rules.Suits)
+	if len(suits) == 0 {
+		suits = []byte{0}
+	}
+
+	var candidates []Card
+	for i := 0; i < len(rules.Order); i++ {
+		for _, s := range suits {
+			candidates = append(candidates, Card{Rank: rules.Order[i], Suit: s})
+		}
+	}
+
+	return candidates
+}
+
+// Gets the index (in Categories) of the strongest category the cards can match, trying every
+// possible substitution for the wildcards.
+func (rules *Rules) bestCategory(cards []Card) int {
+	var resolved []Card
+	wildcards := 0
+	for _, c := range cards {
+		if strings.IndexByte(rules.Wildcards, c.Rank) != -1 {
+			wildcards++
+		} else {
+			resolved = append(resolved, c)
+		}
+	}
+
+	if wildcards == 0 {
+		return rules.classify(cards)
+	}
+
+	// The order of the cards doesn't affect the category, so rather than trying every ordered
+	// assignment, we only try each multiset of substitutions; indices are kept non-decreasing
+	candidates := rules.candidateCards()
+	choice := make([]int, wildcards)
+	fixedLen := len(resolved)
+	best := -1
+	for {
+		resolved = resolved[:fixedLen]
+		for _, idx := range choice {
+			resolved = append(resolved, candidates[idx])
+		}
+
+		best = max(best, rules.classify(resolved))
+		if best == len(rules.Categories)-1 {
+			return best
+		}
+
+		// Advance to the next multiset
+		i := len(choice) - 1
+		for i >= 0 && choice[i] == len(candidates)-1 {
+			i--
+		}
+		if i < 0 {
+			return best
+		}
+		choice[i]++
+		for j := i + 1; j < len(choice); j++ {
+			choice[j] = choice[i]
+		}
+	}
+}
+
+// Compares the hands card-by-card using the rank order.
+func (rules *Rules) compareCards(h1, h2 []Card) int {
+	for i := range h1 {
+		i1 := strings.IndexByte(rules.Order, h1[i].Rank)
+		i2 := strings.IndexByte(rules.Order, h2[i].Rank)
+		if i1 != i2 {
+			return i1 - i2
+		}
+	}
+
+	return 0
+}
+
+// Gets the name of the strongest category the hand can be made into.
+func (rules *Rules) Classify(hand Hand) (string, error) {
+	cards, err := rules.parseCards(hand.Hand)
+	if err != nil {
+		return "", err
+	}
+
+	category := rules.bestCategory(cards)
+	if category == -1 {
+		return "", fmt.Errorf("hand %q matches no category", hand.Hand)
+	}
+
+	return rules.Categories[category].Name, nil
+}
+
+// Ranks the hands from weakest to strongest and gets the total winnings; the sum of each hand's
+// bid multiplied by its rank.
+func (rules *Rules) TotalWinnings(hands []Hand) (int, error) {
+	if len(rules.Categories) == 0 {
+		return 0, errors.New("rules have no categories")
+	}
+
+	// Work out each hand's cards and category up front, rather than on every comparison
+	type rankedHand struct {
+		Bid      int
+		Cards    []Card
+		Category int
+	}
+	var ranked []rankedHand
+	for _, h := range hands {
+		cards, err := rules.parseCards(h.Hand)
+		if err != nil {
+			return 0, err
+		}
+
+		category := rules.bestCategory(cards)
+		if category == -1 {
+			return 0, fmt.Errorf("hand %q matches no category", h.Hand)
+		}
+
+		ranked = append(ranked, rankedHand{h.Bid, cards, category})
+	}
+
+	slices.SortFunc(ranked, func(h1, h2 rankedHand) int {
+		if h1.Category != h2.Category {
+			return h1.Category - h2.Category
+		}
+
+		return rules.compareCards(h1.Cards, h2.Cards)
+	})
+
+	winnings := 0
+	for i, h := range ranked {
+		winnings += (i + 1) * h.Bid
+	}
+
+	return winnings, nil
+}
@@ -0,0 +1,189 @@
+package day07
+
+import (
+	"testing"
+)
+
+// Poker-like rules, with suits, straights and flushes
+var pokerRules = Rules{
+	Order:      "23456789TJQKA",
+	Suits:      "cdhs",
+	HandSize:   5,
+	Categories: []Category{HighCard, OnePair, TwoPair, ThreeOfAKind, Straight, Flush, FullHouse, FourOfAKind, StraightFlush},
+}
+
+// Checks the category of each hand under the given rules
+func checkClassify(t *testing.T, rules *Rules, want map[string]string) {
+	t.Helper()
+
+	for hand, category := range want {
+		got, err := rules.Classify(Hand{Hand: []byte(hand)})
+		if err != nil {
+			t.Errorf("%s: %v", hand, err)
+		} else if got != category {
+			t.Errorf("%s: got %s, want %s", hand, got, category)
+		}
+	}
+}
+
+func TestClassifySample(t *testing.T) {
+	checkClassify(t, &Part1Rules, map[string]string{
+		"32T3K": "One Pair",
+		"T55J5": "Three of a Kind",
+		"KK677": "Two Pair",
+		"KTJJT": "Two Pair",
+		"QQQJA": "Three of a Kind",
+		"AAAAA": "Five of a Kind",
+		"AA8AA": "Four of a Kind",
+		"23332": "Full House",
+		"23456": "High Card",
+	})
+
+	checkClassify(t, &Part2Rules, map[string]string{
+		"32T3K": "One Pair",
+		"T55J5": "Four of a Kind",
+		"KK677": "Two Pair",
+		"KTJJT": "Four of a Kind",
+		"QQQJA": "Four of a Kind",
+		"JJJJJ": "Five of a Kind",
+		"2J3J4": "Three of a Kind",
+		"22J33": "Full House",
+		"2345J": "One Pair",
+	})
+}
+
+func TestTotalWinningsSample(t *testing.T) {
+	hands, err := ReadHands("../inputs/day07_sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		rules *Rules
+		want  int
+	}{
+		{&Part1Rules, 6440},
+		{&Part2Rules, 5905},
+	} {
+		got, err := test.rules.TotalWinnings(hands)
+		if err != nil {
+			t.Error(err)
+		} else if got != test.want {
+			t.Errorf("got %d, want %d", got, test.want)
+		}
+	}
+}
+
+func TestClassifyStraightsAndFlushes(t *testing.T) {
+	checkClassify(t, &pokerRules, map[string]string{
+		"2h3h4h5h6h": "Straight Flush",
+		"2h3d4h5h6h": "Straight",
+		"ThJdQcKsAh": "Straight",
+		"2h9h4h5hKh": "Flush",
+		"AhAdAcKhKd": "Full House",
+		"2h2d2c2s6h": "Four of a Kind",
+		"AhAdAc5h6h": "Three of a Kind",
+		"2h3d4h5h7c": "High Card",
+	})
+
+	// Without suits, no hand is a flush
+	noSuits := pokerRules
+	noSuits.Suits = ""
+	checkClassify(t, &noSuits, map[string]string{
+		"29K45": "High Card",
+		"23456": "Straight",
+	})
+}
+
+func TestWildcardStraights(t *testing.T) {
+	rules := Rules{
+		Order:      "J23456789TQKA",
+		Wildcards:  "J",
+		HandSize:   5,
+		Categories: []Category{HighCard, OnePair, Straight, ThreeOfAKind},
+	}
+	checkClassify(t, &rules, map[string]string{
+		// The joker fills the gap, or extends the run at either end
+		"23J56": "Straight",
+		"3456J": "Straight",
+		"J9TQK": "Straight",
+		// Two jokers can make a straight or three of a kind; three of a kind is stronger here
+		"9TJJK": "Three of a Kind",
+		// No single card completes a straight
+		"23J57": "One Pair",
+	})
+
+	wildPoker := pokerRules
+	wildPoker.Wildcards = "J"
+	checkClassify(t, &wildPoker, map[string]string{
+		"2h3h4hJs6h": "Straight Flush",
+		"2h3h4hJs8h": "Flush",
+		"2h3d4hJs6h": "Straight",
+		"2h2dJhJsJc": "Four of a Kind",
+	})
+}
+
+func TestOtherHandSizes(t *testing.T) {
+	rules := Rules{
+		Order:      "23456789TJQKA",
+		HandSize:   3,
+		Categories: []Category{HighCard, OnePair, ThreeOfAKind, Straight},
+	}
+	checkClassify(t, &rules, map[string]string{
+		"AAA": "Three of a Kind",
+		"AAK": "One Pair",
+		"QKA": "Straight",
+		"27K": "High Card",
+	})
+
+	got, err := rules.TotalWinnings([]Hand{{[]byte("AAK"), 1}, {[]byte("222"), 10}, {[]byte("27K"), 100}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 100 + 2*1 + 3*10; got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+
+	if _, err := rules.TotalWinnings([]Hand{{[]byte("AAKQJ"), 1}}); err == nil {
+		t.Error("expected an error for a 5 card hand with a hand size of 3")
+	}
+}
+
+func TestParseCardsErrors(t *testing.T) {
+	tests := []struct {
+		rules *Rules
+		hand  string
+	}{
+		{&Part1Rules, "32T3X"},
+		{&Part1Rules, "32t3K"},
+		{&Part1Rules, "32T3"},
+		{&Part1Rules, "32T3KK"},
+		{&Part1Rules, ""},
+		{&pokerRules, "2h3h4h5h6x"},
+		{&pokerRules, "2h3h4h5h6"},
+		{&pokerRules, "23456"},
+		{&pokerRules, "1h3h4h5h6h"},
+	}
+
+	for _, test := range tests {
+		if cards, err := test.rules.parseCards([]byte(test.hand)); err == nil {
+			t.Errorf("%q: expected an error, got %v", test.hand, cards)
+		}
+	}
+}
+
+func TestNoMatchingCategory(t *testing.T) {
+	rules := Part1Rules
+	rules.Categories = []Category{OnePair}
+	if _, err := rules.Classify(Hand{Hand: []byte("23456")}); err == nil {
+		t.Error("expected an error for a hand matching no category")
+	}
+	if _, err := rules.TotalWinnings([]Hand{{[]byte("23456"), 1}}); err == nil {
+		t.Error("expected an error for a hand matching no category")
+	}
+
+	rules.Categories = nil
+	if _, err := rules.TotalWinnings([]Hand{{[]byte("22456"), 1}}); err == nil {
+		t.Error("expected an error for rules with no categories")
+	}
+}
@@ -0,0 +1,86 @@
+package day08
+
+import (
+	"aoc/utils/dot"
+	"io"
+	"slices"
+)
+
+// Gets every node reachable from the given start, in the order a breadth-first search finds them.
+func getReachable(nodes map[string]NodeData, start string) []string {
+	visited := map[string]bool{start: true}
+	order := []string{start}
+	for i := 0; i < len(order); i++ {
+		data, ok := nodes[order[i]]
+		if !ok {
+			continue
+		}
+
+		for _, next := range []string{data.Left, data.Right} {
+			if !visited[next] {
+				visited[next] = true
+				order = append(order, next)
+			}
+		}
+	}
+
+	return order
+}
+
+// Builds a graph of the network.  Left edges are drawn solid and right edges dashed; start nodes
+// ("..A") are green and end nodes ("..Z") are red.  Each ghost's reachable subgraph is grouped into
+// a cluster; since a node can only be drawn in one cluster, nodes reachable by several ghosts go in
+// the cluster of the first ghost (in sorted order) that reaches them.
+func buildGraph(nodes map[string]NodeData) *dot.Graph {
+	g := dot.NewGraph("network", true)
+
+	var names []string
+	for k := range nodes {
+		names = append(names, k)
+	}
+	slices.Sort(names)
+
+	clustered := map[string]bool{}
+	for _, name := range names {
+		if name[2] != 'A' {
+			continue
+		}
+
+		var members []string
+		for _, n := range getReachable(nodes, name) {
+			if !clustered[n] {
+				clustered[n] = true
+				members = append(members, n)
+			}
+		}
+		g.AddCluster("ghost "+name, members, dot.Attrs{"color": "gray"})
+	}
+
+	for _, name := range names {
+		attrs := dot.Attrs{}
+		switch name[2] {
+		case 'A':
+			attrs = dot.Attrs{"style": "filled", "fillcolor": "palegreen"}
+		case 'Z':
+			attrs = dot.Attrs{"style": "filled", "fillcolor": "salmon"}
+		}
+		g.AddNode(name, attrs)
+	}
+
+	for _, name := range names {
+		g.AddEdge(name, nodes[name].Left, dot.Attrs{"label": "L", "color": "blue"})
+		g.AddEdge(name, nodes[name].Right, dot.Attrs{"label": "R", "color": "darkorange", "style": "dashed"})
+	}
+
+	return g
+}
+
+// Writes the network in the given input as a Graphviz DOT graph.
+func ExportDOT(path string, w io.Writer) error {
+	_, nodes, err := parseInput(path)
+	if err != nil {
+		return err
+	}
+
+	return buildGraph(nodes).Write(w)
+}
@@ -0,0 +1,61 @@
+package day08
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportDOTSample(t *testing.T) {
+	var s strings.Builder
+	if err := ExportDOT("../inputs/day08_sample.txt", &s); err != nil {
+		t.Fatal(err)
+	}
+
+	// XXX is reachable by both ghosts, so only goes in the first one's cluster
+	want := `digraph "network" {
+  subgraph "cluster_0" {
+    label="ghost 11A";
+    color="gray";
+    "11A";
+    "11B";
+    "XXX";
+    "11Z";
+  }
+  subgraph "cluster_1" {
+    label="ghost 22A";
+    color="gray";
+    "22A";
+    "22B";
+    "22C";
+    "22Z";
+  }
+  "11A" [fillcolor="palegreen", style="filled"];
+  "11B";
+  "11Z" [fillcolor="salmon", style="filled"];
+  "22A" [fillcolor="palegreen", style="filled"];
+  "22B";
+  "22C";
+  "22Z" [fillcolor="salmon", style="filled"];
+  "XXX";
+  "11A" -> "11B" [color="blue", label="L"];
+  "11A" -> "XXX" [color="darkorange", label="R", style="dashed"];
+  "11B" -> "XXX" [color="blue", label="L"];
+  "11B" -> "11Z" [color="darkorange", label="R", style="dashed"];
+  "11Z" -> "11B" [color="blue", label="L"];
+  "11Z" -> "XXX" [color="darkorange", label="R", style="dashed"];
+  "22A" -> "22B" [color="blue", label="L"];
+  "22A" -> "XXX" [color="darkorange", label="R", style="dashed"];
+  "22B" -> "22C" [color="blue", label="L"];
+  "22B" -> "22C" [color="darkorange", label="R", style="dashed"];
+  "22C" -> "22Z" [color="blue", label="L"];
+  "22C" -> "22Z" [color="darkorange", label="R", style="dashed"];
+  "22Z" -> "22B" [color="blue", label="L"];
+  "22Z" -> "22B" [color="darkorange", label="R", style="dashed"];
+  "XXX" -> "XXX" [color="blue", label="L"];
+  "XXX" -> "XXX" [color="darkorange", label="R", style="dashed"];
+}
+`
+	if got := s.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,185 @@
+package day08
+
+import (
+	"aoc/utils"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// A ghost's position at a point in time; since the directions repeat, the same node at the same
+// direction index always leads to the same future.
+type ghostState struct {
+	Node   string
+	DirIdx int
+}
+
+// The steps at which a single ghost is on an end node.  The ghost's path eventually loops, so its
+// hits are a finite set before the loop starts, plus a set of hits within the loop which repeat
+// forever.
+type ghostCycle struct {
+	// The number of steps before the ghost enters its loop
+	PrePeriod int
+	// The length of the loop
+	Period int
+	// Steps (less than PrePeriod) at which the ghost is on an end node
+	PreHits []int
+	// Steps in [PrePeriod, PrePeriod + Period) at which the ghost is on an end node; each repeats
+	// every Period steps
+	CycleHits []int
+}
+
+// Returns whether or not the ghost is on an end node after the given number of steps.
+func (g *ghostCycle) hitsAt(step int) bool {
+	if step < g.PrePeriod {
+		return slices.Contains(g.PreHits, step)
+	}
+
+	offset := g.PrePeriod + (step-g.PrePeriod)%g.Period
+	return slices.Contains(g.CycleHits, offset)
+}
+
+// Walks the ghost from its starting node until it repeats a state, recording where it's on an
+// end node along the way.
+func findGhostCycle(nodes map[string]NodeData, start string, isEndNode func(string) bool, directions string) (ghostCycle, error) {
+	seen := map[ghostState]int{}
+	var hits []int
+
+	state := ghostState{start, 0}
+	for step := 0; ; step++ {
+		if first, ok := seen[state]; ok {
+			cycle := ghostCycle{PrePeriod: first, Period: step - first}
+			for _, h := range hits {
+				if h < first {
+					cycle.PreHits = append(cycle.PreHits, h)
+				} else {
+					cycle.CycleHits = append(cycle.CycleHits, h)
+				}
+			}
+
+			return cycle, nil
+		}
+		seen[state] = step
+
+		if _, ok := nodes[state.Node]; !ok {
+			return ghostCycle{}, fmt.Errorf("node %q does not exist", state.Node)
+		}
+		if isEndNode(state.Node) {
+			hits = append(hits, step)
+		}
+
+		state = ghostState{followDirection(nodes, state.Node, directions[state.DirIdx]), (state.DirIdx + 1) % len(directions)}
+	}
+}
+
+// Finds the first step at which every ghost is on an end node at the same time, or returns false
+// if that never happens.
+func firstSimultaneousHit(ghosts []ghostCycle) (int, bool, error) {
+	if len(ghosts) == 0 {
+		return 0, false, errors.New("no ghosts")
+	}
+
+	// Before every ghost is in its loop, we just check each step directly
+	settled := 0
+	for _, g := range ghosts {
+		settled = max(settled, g.PrePeriod)
+	}
+	for step := 0; step < settled; step++ {
+		all := true
+		for i := range ghosts {
+			if !ghosts[i].hitsAt(step) {
+				all = false
+				break
+			}
+		}
+		if all {
+			return step, true, nil
+		}
+	}
+
+	// After that, ghost g is on an end node exactly when the step is congruent to one of its cycle
+	// hits modulo its period.  Combine every choice of hit for each ghost into the set of
+	// congruences satisfied by a simultaneous hit.
+	candidates := []utils.Congruence{{Remainder: big.NewInt(0), Modulus: big.NewInt(1)}}
+	for _, g := range ghosts {
+		var next []utils.Congruence
+		seen := map[string]bool{}
+		for _, c := range candidates {
+			for _, h := range g.CycleHits {
+				combined, ok := utils.CombineCongruences(c, utils.NewCongruence(h, g.Period))
+				if !ok {
+					continue
+				}
+
+				key := combined.Remainder.String() + "/" + combined.Modulus.String()
+				if !seen[key] {
+					seen[key] = true
+					next = append(next, combined)
+				}
+			}
+		}
+
+		candidates = next
+		if len(candidates) == 0 {
+			return 0, false, nil
+		}
+	}
+
+	// The answer is the smallest number >= settled satisfying any of the congruences
+	var best *big.Int
+	bigSettled := big.NewInt(int64(settled))
+	for _, c := range candidates {
+		step := new(big.Int).Set(c.Remainder)
+		if step.Cmp(bigSettled) < 0 {
+			// Round up to the first value >= settled in the same residue class
+			gap := new(big.Int).Sub(bigSettled, step)
+			gap.Add(gap, c.Modulus).Sub(gap, big.NewInt(1)).Quo(gap, c.Modulus)
+			step.Add(step, gap.Mul(gap, c.Modulus))
+		}
+
+		if best == nil || step.Cmp(best) < 0 {
+			best = step
+		}
+	}
+
+	if !best.IsInt64() {
+		return 0, false, fmt.Errorf("first simultaneous step %s is too large", best.String())
+	}
+
+	return int(best.Int64()), true, nil
+}
+
+// Finds the first step at which every ghost (starting from every node ending in 'A') is on a node
+// ending in 'Z' at the same time.  Unlike taking the LCM of each ghost's first hit, this works for
+// any input; ghosts may take a while to reach their loop, and may pass through any number of end
+// nodes at any offsets within it.  Returns an error if the ghosts never line up.
+func FirstSimultaneousArrival(path string) (int, error) {
+	directions, nodes, err := parseInput(path)
+	if err != nil {
+		return 0, err
+	}
+
+	var ghosts []ghostCycle
+	for k := range nodes {
+		if k[2] != 'A' {
+			continue
+		}
+
+		g, err := findGhostCycle(nodes, k, func(s string) bool { return s[2] == 'Z' }, directions)
+		if err != nil {
+			return 0, err
+		}
+		ghosts = append(ghosts, g)
+	}
+
+	step, ok, err := firstSimultaneousHit(ghosts)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, errors.New("ghosts are never all on end nodes at the same time")
+	}
+
+	return step, nil
+}
@@ -0,0 +1,168 @@
+package day08
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Writes a network to a temporary file.  nodes maps each node to its left and right nodes.
+func writeNetwork(t *testing.T, directions string, nodes map[string][2]string) string {
+	var s strings.Builder
+	fmt.Fprintf(&s, "%s\n\n", directions)
+	for name, next := range nodes {
+		fmt.Fprintf(&s, "%s = (%s, %s)\n", name, next[0], next[1])
+	}
+
+	path := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(path, []byte(s.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+// Finds the first step every ghost is on an end node by moving them all a step at a time, giving up
+// after the given number of steps.
+func bruteForceArrival(directions string, nodes map[string][2]string, limit int) (int, bool) {
+	var ghosts []string
+	for name := range nodes {
+		if name[2] == 'A' {
+			ghosts = append(ghosts, name)
+		}
+	}
+
+	for step := 0; step <= limit; step++ {
+		all := true
+		for _, g := range ghosts {
+			all = all && g[2] == 'Z'
+		}
+		if all {
+			return step, true
+		}
+
+		side := 0
+		if directions[step%len(directions)] == 'R' {
+			side = 1
+		}
+		for i, g := range ghosts {
+			ghosts[i] = nodes[g][side]
+		}
+	}
+
+	return 0, false
+}
+
+func TestFirstSimultaneousArrival(t *testing.T) {
+	tests := []struct {
+		name       string
+		directions string
+		nodes      map[string][2]string
+		want       int
+		wantOk     bool
+	}{
+		{"sample", "LR", map[string][2]string{
+			"11A": {"11B", "XXX"}, "11B": {"XXX", "11Z"}, "11Z": {"11B", "XXX"},
+			"22A": {"22B", "XXX"}, "22B": {"22C", "22C"}, "22C": {"22Z", "22Z"}, "22Z": {"22B", "22B"},
+			"XXX": {"XXX", "XXX"},
+		}, 6, true},
+		// One ghost is only on an end node at odd steps, the other only at even ones
+		{"never lines up", "L", map[string][2]string{
+			"11A": {"11Z", "11Z"}, "11Z": {"11A", "11A"},
+			"22A": {"22B", "22B"}, "22B": {"22Z", "22Z"}, "22Z": {"22B", "22B"},
+		}, 0, false},
+		// The first ghost passes an end node once on its way into a loop with none
+		{"only before the loop", "L", map[string][2]string{
+			"11A": {"11B", "11B"}, "11B": {"11Z", "11Z"}, "11Z": {"11C", "11C"}, "11C": {"11C", "11C"},
+			"22A": {"22Z", "22Z"}, "22Z": {"22A", "22A"},
+		}, 0, false},
+		{"before the loop", "L", map[string][2]string{
+			"11A": {"11B", "11B"}, "11B": {"11Z", "11Z"}, "11Z": {"11C", "11C"}, "11C": {"11C", "11C"},
+			"22A": {"22B", "22B"}, "22B": {"22Z", "22Z"}, "22Z": {"22A", "22A"},
+		}, 2, true},
+		// Loops of length 4 and 5 which line up at step 19, rather than at their LCM
+		{"offset loops", "L", map[string][2]string{
+			"11A": {"11B", "11B"}, "11B": {"11C", "11C"}, "11C": {"11Z", "11Z"}, "11Z": {"11D", "11D"}, "11D": {"11B", "11B"},
+			"22A": {"22B", "22B"}, "22B": {"22C", "22C"}, "22C": {"22D", "22D"}, "22D": {"22Z", "22Z"},
+			"22Z": {"22E", "22E"}, "22E": {"22F", "22F"}, "22F": {"22C", "22C"},
+		}, 19, true},
+		{"no end nodes", "L", map[string][2]string{
+			"11A": {"11A", "11A"},
+		}, 0, false},
+	}
+
+	for _, test := range tests {
+		got, err := FirstSimultaneousArrival(writeNetwork(t, test.directions, test.nodes))
+		if !test.wantOk {
+			if err == nil {
+				t.Errorf("%s: got %d, expected the ghosts never to line up", test.name, got)
+			}
+		} else if err != nil || got != test.want {
+			t.Errorf("%s: got %d, %v; want %d", test.name, got, err, test.want)
+		}
+
+		want, ok := bruteForceArrival(test.directions, test.nodes, 1000)
+		if ok != test.wantOk || want != test.want {
+			t.Errorf("%s: brute force gave %d, %t; the test expects %d, %t", test.name, want, ok, test.want, test.wantOk)
+		}
+	}
+}
+
+func TestFirstSimultaneousArrivalMatchesBruteForce(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const maxNodes, maxDirections, maxGhosts = 8, 4, 3
+	// No ghost has more states than this, so none takes longer to reach its loop, or has a longer
+	// loop.  Once every ghost is in its loop, they're all back where they were after the product of
+	// their loop lengths, so if they haven't lined up by then they never will.
+	const maxStates = maxNodes * maxDirections
+	period := 1
+	for i := 0; i < maxGhosts; i++ {
+		period *= maxStates
+	}
+	limit := maxStates + period
+
+	lined, never := 0, 0
+	for i := 0; i < 500; i++ {
+		numNodes := 2 + rng.Intn(maxNodes-1)
+		numGhosts := 1 + rng.Intn(maxGhosts)
+
+		names := make([]string, numNodes)
+		for n := range names {
+			last := "BZ"[rng.Intn(2)]
+			if n < numGhosts {
+				last = 'A'
+			}
+			names[n] = fmt.Sprintf("%02d%c", n, last)
+		}
+
+		nodes := map[string][2]string{}
+		for _, name := range names {
+			nodes[name] = [2]string{names[rng.Intn(numNodes)], names[rng.Intn(numNodes)]}
+		}
+
+		var directions strings.Builder
+		for d := 1 + rng.Intn(maxDirections); d > 0; d-- {
+			directions.WriteByte("LR"[rng.Intn(2)])
+		}
+
+		want, ok := bruteForceArrival(directions.String(), nodes, limit)
+		got, err := FirstSimultaneousArrival(writeNetwork(t, directions.String(), nodes))
+		if ok != (err == nil) || (ok && got != want) {
+			t.Fatalf("directions %s, nodes %v: got %d, %v; brute force gave %d, %t", directions.String(), nodes, got, err, want, ok)
+		}
+
+		if ok {
+			lined++
+		} else {
+			never++
+		}
+	}
+
+	// Make sure the random networks cover both outcomes
+	if lined == 0 || never == 0 {
+		t.Errorf("%d networks lined up and %d never did; expected some of each", lined, never)
+	}
+}
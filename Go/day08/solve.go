@@ -3,6 +3,7 @@ package day08
 import (
 	"aoc/utils"
 	"bufio"
+	"fmt"
 	"os"
 )
 
@@ -11,6 +12,16 @@ type NodeData struct {
 	Right string
 }
 
+// A line of input describing a node
+type nodeLine struct {
+	_     struct{} `pattern:"{Name} = ({Left}, {Right})"`
+	Name  string
+	Left  string
+	Right string
+}
+
+var nodeParser = utils.MustLineParser[nodeLine]()
+
 func parseInput(path string) (string, map[string]NodeData, error) {
 	f, err := os.Open(path)
 	if err != nil {
@@ -32,10 +43,21 @@ func parseInput(path string) (string, map[string]NodeData, error) {
 		return "", nil, err
 	}
 
+	lines, err := utils.ReadItems(scanner, nodeParser.Parse, true)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Start and end nodes are picked out by their last character, so names must all be the same
+	// length
 	nodes := map[string]NodeData{}
-	for scanner.Scan() {
-		text := scanner.Text()
-		nodes[text[:3]] = NodeData{text[7:10], text[12:15]}
+	for _, line := range lines {
+		for _, name := range []string{line.Name, line.Left, line.Right} {
+			if len(name) != 3 {
+				return "", nil, fmt.Errorf("node %s: name %q is not 3 characters long", line.Name, name)
+			}
+		}
+		nodes[line.Name] = NodeData{line.Left, line.Right}
 	}
 
 	return directions, nodes, nil
@@ -75,17 +97,8 @@ func PartA(path string) int {
 }
 
 func PartB(path string) int {
-	directions, nodes, err := parseInput(path)
+	steps, err := FirstSimultaneousArrival(path)
 	utils.CheckError(err)
 
-	var paths []int
-	for k := range nodes {
-		if k[2] != 'A' {
-			continue
-		}
-
-		paths = append(paths, getStepsForPath(nodes, k, func(s string) bool { return s[2] == 'Z' }, directions))
-	}
-
-	return utils.LCM(paths[0], paths[1], paths[2:]...)
+	return steps
 }
@@ -0,0 +1,28 @@
+package day08
+
+import (
+	"aoc/utils/testutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func BenchmarkDay(b *testing.B) {
+	testutil.BenchmarkDay(b, 8, func(path string) error { _, _, err := parseInput(path); return err }, PartA, PartB)
+}
+
+func TestParseRejectsShortNames(t *testing.T) {
+	for _, input := range []string{
+		"L\n\nAA = (BBB, BBB)\nBBB = (BBB, BBB)\n",
+		"L\n\nAAA = (B, B)\n",
+	} {
+		path := filepath.Join(t.TempDir(), "input.txt")
+		if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, _, err := parseInput(path); err == nil {
+			t.Errorf("expected an error parsing %q", input)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package day09
 
 import (
 	"aoc/utils"
+	"aoc/utils/poly"
 	"bufio"
 	"os"
 	"strconv"
@@ -30,46 +31,43 @@ func parseInput(path string) ([][]int, error) {
 	return result, nil
 }
 
-func getDiffSequence(seq []int) []int {
-	var diffSeq []int
-	for i := 1; i < len(seq); i += 1 {
-		diffSeq = append(diffSeq, seq[i]-seq[i-1])
+// Extrapolates each sequence to the given index, relative to the start of the sequence.  Index
+// len(seq) is the next value, and -1 is the value before the first.
+func sumExtrapolated(nums [][]int, index func(seq []int) int) (int, error) {
+	sum := 0
+	for _, seq := range nums {
+		v, err := poly.Fit(seq).EvalInt(index(seq))
+		if err != nil {
+			return 0, err
+		}
+
+		sum += v
 	}
 
-	return diffSeq
+	return sum, nil
 }
 
-func isZeroSeq(seq []int) bool {
-	for _, v := range seq {
-		if v != 0 {
-			return false
-		}
+// Gets the minimal-degree polynomial through each sequence in the given input.
+func FitSequences(path string) ([]poly.Polynomial, error) {
+	nums, err := parseInput(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []poly.Polynomial
+	for _, seq := range nums {
+		result = append(result, poly.Fit(seq))
 	}
 
-	return true
+	return result, nil
 }
 
 func PartA(path string) int {
 	nums, err := parseInput(path)
 	utils.CheckError(err)
 
-	sum := 0
-	for _, seq := range nums {
-		diffs := [][]int{seq}
-		for {
-			diffs = append(diffs, getDiffSequence(diffs[len(diffs)-1]))
-			if isZeroSeq(diffs[len(diffs)-1]) {
-				break
-			}
-		}
-
-		diffs[len(diffs)-1] = append(diffs[len(diffs)-1], 0)
-		for i := len(diffs) - 2; i >= 0; i-- {
-			diffs[i] = append(diffs[i], diffs[i][len(diffs[i])-1]+diffs[i+1][len(diffs[i+1])-1])
-		}
-
-		sum += diffs[0][len(diffs[0])-1]
-	}
+	sum, err := sumExtrapolated(nums, func(seq []int) int { return len(seq) })
+	utils.CheckError(err)
 
 	return sum
 }
@@ -78,23 +76,8 @@ func PartB(path string) int {
 	nums, err := parseInput(path)
 	utils.CheckError(err)
 
-	sum := 0
-	for _, seq := range nums {
-		diffs := [][]int{seq}
-		for {
-			diffs = append(diffs, getDiffSequence(diffs[len(diffs)-1]))
-			if isZeroSeq(diffs[len(diffs)-1]) {
-				break
-			}
-		}
-
-		diffs[len(diffs)-1] = utils.InsertToSlice(diffs[len(diffs)-1], 0, 0)
-		for i := len(diffs) - 2; i >= 0; i-- {
-			diffs[i] = utils.InsertToSlice(diffs[i], 0, diffs[i][0]-diffs[i+1][0])
-		}
-
-		sum += diffs[0][0]
-	}
+	sum, err := sumExtrapolated(nums, func(seq []int) int { return -1 })
+	utils.CheckError(err)
 
 	return sum
 }
@@ -0,0 +1,128 @@
+package day10
+
+import (
+	"aoc/utils"
+	"aoc/utils/geom"
+	"aoc/utils/vis"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// The main loop of a pipe grid, along with the tiles it encloses.
+type LoopInterior struct {
+	// The grid the loop was found in (with the start tile replaced by its actual pipe)
+	Grid utils.Grid[byte]
+	// Positions of the main loop, in the order they are visited when walking the loop from the start
+	Loop []utils.Point
+	// Positions which are enclosed by the main loop
+	Inside map[utils.Point]bool
+}
+
+// Box-drawing characters corresponding to each pipe character
+var pipeToBoxDrawing = map[byte]rune{
+	'|': '│',
+	'-': '─',
+	'L': '└',
+	'J': '┘',
+	'7': '┐',
+	'F': '┌',
+}
+
+// Walks the main loop starting from the given position, and returns each position along it in order.
+// The start position is the first element, and is NOT repeated at the end.
+func traceLoop(grid *utils.Grid[byte], start utils.Point) []utils.Point {
+	loop := []utils.Point{start}
+
+	// Each pipe has exactly 2 connections; so we just pick one to start, and from then on always
+	// take whichever connection doesn't lead back where we came from
+	dir := charToNeighbors[grid.GetCopy(start)][0]
+	cur := start.Add(dir)
+	for cur != start {
+		loop = append(loop, cur)
+
+		cameFrom := utils.GetOppositeDir(dir)
+		for _, d := range charToNeighbors[grid.GetCopy(cur)] {
+			if d != cameFrom {
+				dir = d
+				break
+			}
+		}
+		cur = cur.Add(dir)
+	}
+
+	return loop
+}
+
+// Finds the main loop for the given input, and the tiles it encloses.  The enclosed tiles are
+// found via scanline parity, and the count is cross-checked against the count given by the
+// shoelace formula and Pick's theorem; an error is returned if the two disagree.
+func GetLoopInterior(path string) (LoopInterior, error) {
+	grid, start, err := parseInput(path)
+	if err != nil {
+		return LoopInterior{}, err
+	}
+
+	mainLoop := findLoop(&grid, start, nil)
+	loop := traceLoop(&grid, start)
+	if len(loop) != len(mainLoop) {
+		return LoopInterior{}, fmt.Errorf("traced loop has %d tiles but search found %d", len(loop), len(mainLoop))
+	}
+
+	inside := findInsidePoints(&grid, mainLoop)
+	if picks := geom.InteriorPoints(loop); picks != len(inside) {
+		return LoopInterior{}, fmt.Errorf("scanline found %d inside tiles but pick's theorem gives %d", len(inside), picks)
+	}
+
+	return LoopInterior{Grid: grid, Loop: loop, Inside: inside}, nil
+}
+
+// Renders the grid with the main loop drawn using box-drawing characters, and the enclosed tiles
+// highlighted.  Tiles which are neither on the loop nor inside it are drawn as blanks.
+func (li *LoopInterior) Render() string {
+	onLoop := map[utils.Point]bool{}
+	for _, p := range li.Loop {
+		onLoop[p] = true
+	}
+
+	var s strings.Builder
+	for y := 0; y < li.Grid.Height(); y++ {
+		for x := 0; x < li.Grid.Width(); x++ {
+			pos := utils.Point{X: x, Y: y}
+			switch {
+			case onLoop[pos]:
+				s.WriteRune(pipeToBoxDrawing[li.Grid.GetCopy(pos)])
+			case li.Inside[pos]:
+				// Green background
+				s.WriteString("\x1b[42mI\x1b[0m")
+			default:
+				s.WriteByte(' ')
+			}
+		}
+		s.WriteByte('\n')
+	}
+
+	return s.String()
+}
+
+// Runs the search for the main loop on the given input, showing each distance from the start to
+// the observer, followed by the tiles the loop encloses.
+func Visualize(path string, obs vis.Observer) error {
+	grid, start, err := parseInput(path)
+	if err != nil {
+		return err
+	}
+
+	obs.Begin(grid)
+	mainLoop := findLoop(&grid, start, obs)
+
+	var cells []vis.Cell
+	for p := range findInsidePoints(&grid, mainLoop) {
+		cells = append(cells, vis.Cell{Pos: p, Char: 'I', Style: vis.Marked})
+	}
+	// Sort so the frame is the same every run
+	slices.SortFunc(cells, func(a, b vis.Cell) int { return a.Pos.ToIndex(grid.Width()) - b.Pos.ToIndex(grid.Width()) })
+	obs.Frame(vis.Frame{Label: "enclosed tiles", Cells: cells})
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package day10
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The part 2 examples from the puzzle, along with the number of tiles each loop encloses and how
+// they are rendered (with blanks shown as '.' and the highlighting removed)
+var interiorExamples = []struct {
+	grid   string
+	inside int
+	render string
+}{
+	{
+		`...........
+.S-------7.
+.|F-----7|.
+.||.....||.
+.||.....||.
+.|L-7.F-J|.
+.|..|.|..|.
+.L--J.L--J.
+...........`,
+		4,
+		`...........
+.┌───────┐.
+.│┌─────┐│.
+.││.....││.
+.││.....││.
+.│└─┐.┌─┘│.
+.│II│.│II│.
+.└──┘.└──┘.
+...........`,
+	},
+	{
+		`.F----7F7F7F7F-7....
+.|F--7||||||||FJ....
+.||.FJ||||||||L7....
+FJL7L7LJLJ||LJ.L-7..
+L--J.L7...LJS7F-7L7.
+....F-J..F7FJ|L7L7L7
+....L7.F7||L7|.L7L7|
+.....|FJLJ|FJ|F7|.LJ
+....FJL-7.||.||||...
+....L---J.LJ.LJLJ...`,
+		8,
+		`.┌────┐┌┐┌┐┌┐┌─┐....
+.│┌──┐││││││││┌┘....
+.││.┌┘││││││││└┐....
+┌┘└┐└┐└┘└┘││└┘I└─┐..
+└──┘.└┐III└┘┌┐┌─┐└┐.
+....┌─┘II┌┐┌┘│└┐└┐└┐
+....└┐I┌┐││└┐│I└┐└┐│
+.....│┌┘└┘│┌┘│┌┐│.└┘
+....┌┘└─┐.││.││││...
+....└───┘.└┘.└┘└┘...`,
+	},
+	{
+		`FF7FSF7F7F7F7F7F---7
+L|LJ||||||||||||F--J
+FL-7LJLJ||||||LJL-77
+F--JF--7||LJLJ7F7FJ-
+L---JF-JLJ.||-FJLJJ7
+|F|F-JF---7F7-L7L|7|
+|FFJF7L7F-JF7|JL---7
+7-L-JL7||F7|L7F-7F7|
+L.L7LFJ|||||FJL7||LJ
+L7JLJL-JLJLJL--JLJ.L`,
+		10,
+		`.┌┐┌┐┌┐┌┐┌┐┌┐┌┐┌───┐
+.│└┘││││││││││││┌──┘
+.└─┐└┘└┘││││││└┘└─┐.
+┌──┘┌──┐││└┘└┘I┌┐┌┘.
+└───┘┌─┘└┘IIII┌┘└┘..
+...┌─┘┌───┐III└┐....
+..┌┘┌┐└┐┌─┘┌┐II└───┐
+..└─┘└┐││┌┐│└┐┌─┐┌┐│
+.....┌┘│││││┌┘└┐││└┘
+.....└─┘└┘└┘└──┘└┘..`,
+	},
+}
+
+func TestGetLoopInterior(t *testing.T) {
+	for i, example := range interiorExamples {
+		path := filepath.Join(t.TempDir(), "input.txt")
+		if err := os.WriteFile(path, []byte(example.grid+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		li, err := GetLoopInterior(path)
+		if err != nil {
+			t.Errorf("example %d: %v", i+1, err)
+			continue
+		}
+		if len(li.Inside) != example.inside {
+			t.Errorf("example %d: got %d inside tiles, want %d", i+1, len(li.Inside), example.inside)
+		}
+		if got := PartB(path); got != example.inside {
+			t.Errorf("example %d: PartB gave %d, want %d", i+1, got, example.inside)
+		}
+
+		rendered := li.Render()
+		if got := strings.Count(rendered, "\x1b[42mI\x1b[0m"); got != example.inside {
+			t.Errorf("example %d: %d tiles highlighted, want %d", i+1, got, example.inside)
+		}
+
+		plain := strings.NewReplacer("\x1b[42m", "", "\x1b[0m", "", " ", ".").Replace(rendered)
+		if want := example.render + "\n"; plain != want {
+			t.Errorf("example %d: got render:\n%s\nwant:\n%s", i+1, plain, want)
+		}
+	}
+}
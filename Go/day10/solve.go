@@ -2,7 +2,9 @@ package day10
 
 import (
 	"aoc/utils"
+	"aoc/utils/vis"
 	"errors"
+	"fmt"
 	"os"
 	"slices"
 )
@@ -93,11 +95,25 @@ func parseInput(path string) (utils.Grid[byte], utils.Point, error) {
 
 // Given a starting position and grid, returns the distances from start of all positions on the main loop
 // (including the start) using BFS.  Any value NOT in the resulting map can be assumed to NOT be a part
-// of the main loop
-func findLoop(grid *utils.Grid[byte], start utils.Point) map[utils.Point]int {
+// of the main loop.  If obs is non-nil, each distance from the start is shown to it as a frame.
+func findLoop(grid *utils.Grid[byte], start utils.Point, obs vis.Observer) map[utils.Point]int {
 	// Nodes visited and distances found to each node
 	distances := map[utils.Point]int{}
 
+	// Positions at the distance currently being searched, and the one before, for the observer
+	var layer, prevLayer []utils.Point
+	layerDist := 0
+	sendLayer := func() {
+		var cells []vis.Cell
+		for _, p := range prevLayer {
+			cells = append(cells, vis.Cell{Pos: p, Char: grid.GetCopy(p), Style: vis.Highlight})
+		}
+		for _, p := range layer {
+			cells = append(cells, vis.Cell{Pos: p, Char: grid.GetCopy(p), Style: vis.Active})
+		}
+		obs.Frame(vis.Frame{Label: fmt.Sprintf("distance %d", layerDist), Cells: cells})
+	}
+
 	// Queue starts with just the start position
 	queue := []QueueNode{{Position: start, Distance: 0}}
 
@@ -114,6 +130,14 @@ func findLoop(grid *utils.Grid[byte], start utils.Point) map[utils.Point]int {
 
 		// Mark node as visited, and the distance we've found from wherever we came from
 		distances[cur.Position] = cur.Distance
+		if obs != nil {
+			if cur.Distance != layerDist {
+				sendLayer()
+				layer, prevLayer = prevLayer[:0], layer
+				layerDist = cur.Distance
+			}
+			layer = append(layer, cur.Position)
+		}
 
 		// For each node connected to this one (according to our pipe diagram), add it to the queue
 		// if it's within the grid and not visited.  The directions which are part of the pipe
@@ -129,6 +153,13 @@ func findLoop(grid *utils.Grid[byte], start utils.Point) map[utils.Point]int {
 		}
 	}
 
+	if obs != nil {
+		sendLayer()
+		// Leave the last layer highlighted like the rest
+		prevLayer, layer = layer, nil
+		sendLayer()
+	}
+
 	return distances
 }
 
@@ -136,7 +167,7 @@ func PartA(path string) int {
 	grid, start, err := parseInput(path)
 	utils.CheckError(err)
 
-	mainLoop := findLoop(&grid, start)
+	mainLoop := findLoop(&grid, start, nil)
 
 	// Find the max distance of any position
 	maxVal := 0
@@ -149,12 +180,9 @@ func PartA(path string) int {
 	return maxVal
 }
 
-func PartB(path string) int {
-	grid, start, err := parseInput(path)
-	utils.CheckError(err)
-
-	mainLoop := findLoop(&grid, start)
-
+// Given the grid and the set of positions on the main loop (as returned by findLoop), returns the
+// set of positions which are enclosed by the main loop.
+func findInsidePoints(grid *utils.Grid[byte], mainLoop map[utils.Point]int) map[utils.Point]bool {
 	// The trick here is to realize that the loop is always a closed polygon by definition;
 	// this opens up a number of methods (shoelace algorithm for area, etc.  Here, I decided to use
 	// a typical polygon scanning algorithms from 2D computer graphics.  To figure out if areas are
@@ -185,7 +213,7 @@ func PartB(path string) int {
 	//
 	// So, we will count instances of '|', 'F', and '7' _that occur on the main loop_ to be
 	// "crossing" the boundary in our count.
-	numInner := 0
+	inside := map[utils.Point]bool{}
 	for y := 0; y < grid.Height(); y++ {
 		isInner := false
 		for x := 0; x < grid.Width(); x++ {
@@ -204,7 +232,7 @@ func PartB(path string) int {
 			switch val {
 			case '.':
 				if isInner {
-					numInner++
+					inside[pos] = true
 				}
 			case 'F':
 				fallthrough
@@ -216,5 +244,14 @@ func PartB(path string) int {
 		}
 	}
 
-	return numInner
+	return inside
+}
+
+func PartB(path string) int {
+	grid, start, err := parseInput(path)
+	utils.CheckError(err)
+
+	mainLoop := findLoop(&grid, start, nil)
+
+	return len(findInsidePoints(&grid, mainLoop))
 }
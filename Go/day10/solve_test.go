@@ -0,0 +1,10 @@
+package day10
+
+import (
+	"aoc/utils/testutil"
+	"testing"
+)
+
+func BenchmarkDay(b *testing.B) {
+	testutil.BenchmarkDay(b, 10, func(path string) error { _, _, err := parseInput(path); return err }, PartA, PartB)
+}
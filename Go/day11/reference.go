@@ -0,0 +1,69 @@
+//go:build reference
+
+package day11
+
+import "aoc/utils"
+
+// These count steps between each pair of galaxies one row and column at a time, where the real
+// solution moves the galaxies themselves to where the expansion leaves them.
+
+// Sums the distances between every pair of galaxies by walking from one to the other a row and a
+// column at a time, where each empty row or column crossed counts expansionFactor times.
+func referenceSumDistances(galaxyMap utils.Grid[byte], expansionFactor int) int {
+	var galaxies []utils.Point
+	emptyRows := make([]bool, galaxyMap.Height())
+	emptyCols := make([]bool, galaxyMap.Width())
+	for y := range emptyRows {
+		emptyRows[y] = true
+	}
+	for x := range emptyCols {
+		emptyCols[x] = true
+	}
+
+	posIt := galaxyMap.Positions()
+	for posIt.Next() {
+		p := posIt.Current()
+		if galaxyMap.GetCopy(p) == '#' {
+			galaxies = append(galaxies, p)
+			emptyRows[p.Y] = false
+			emptyCols[p.X] = false
+		}
+	}
+
+	// Gets the cost of crossing from one row (or column) to another
+	walk := func(empty []bool, from, to int) int {
+		from, to = min(from, to), max(from, to)
+		cost := 0
+		for i := from + 1; i <= to; i++ {
+			if empty[i] {
+				cost += expansionFactor
+			} else {
+				cost++
+			}
+		}
+		return cost
+	}
+
+	sum := 0
+	for i, g1 := range galaxies {
+		for _, g2 := range galaxies[i+1:] {
+			sum += walk(emptyRows, g1.Y, g2.Y) + walk(emptyCols, g1.X, g2.X)
+		}
+	}
+
+	return sum
+}
+
+func ReferencePartA(path string) int {
+	galaxyMap, err := parseInput(path)
+	utils.CheckError(err)
+
+	return referenceSumDistances(galaxyMap, 2)
+}
+
+func ReferencePartB(path string) int {
+	galaxyMap, err := parseInput(path)
+	utils.CheckError(err)
+
+	return referenceSumDistances(galaxyMap, 1000000)
+}
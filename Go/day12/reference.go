@@ -0,0 +1,122 @@
+//go:build reference
+
+package day12
+
+import (
+	"aoc/utils"
+	"slices"
+)
+
+// Part A tries every way of filling in the unknown springs, and part B fills in a table rather
+// than using the real solution's memoised recursion.
+
+// Gets the sizes of the groups of damaged springs in a row with no unknowns
+func referenceGroups(springs []byte) []int {
+	var groups []int
+	run := 0
+	for _, s := range springs {
+		if s == '#' {
+			run++
+		} else if run > 0 {
+			groups = append(groups, run)
+			run = 0
+		}
+	}
+	if run > 0 {
+		groups = append(groups, run)
+	}
+
+	return groups
+}
+
+// Counts arrangements by trying every combination of the unknown springs.
+func referenceCountBruteForce(row Row) int {
+	var unknowns []int
+	for i, s := range row.Springs {
+		if s == '?' {
+			unknowns = append(unknowns, i)
+		}
+	}
+
+	springs := slices.Clone(row.Springs)
+	count := 0
+	for mask := 0; mask < 1<<len(unknowns); mask++ {
+		for bit, i := range unknowns {
+			springs[i] = '.'
+			if mask&(1<<bit) != 0 {
+				springs[i] = '#'
+			}
+		}
+
+		if slices.Equal(referenceGroups(springs), row.Groups) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Counts arrangements with a table, since unfolded rows have far too many unknowns to try every
+// combination.  ways[i][j] is the number of arrangements of springs i onwards into groups j onwards;
+// at each spring, either it's operational and we move on one, or group j starts there.
+func referenceCountTable(row Row) int {
+	n, numGroups := len(row.Springs), len(row.Groups)
+	ways := make([][]int, n+2)
+	for i := range ways {
+		ways[i] = make([]int, numGroups+1)
+	}
+
+	// Past the end, there's one way to place no groups (and any springs after a group's gap are
+	// past the end too)
+	ways[n][numGroups] = 1
+	ways[n+1][numGroups] = 1
+
+	for i := n - 1; i >= 0; i-- {
+		for j := numGroups; j >= 0; j-- {
+			if row.Springs[i] != '#' {
+				ways[i][j] += ways[i+1][j]
+			}
+
+			if j == numGroups {
+				continue
+			}
+			g := row.Groups[j]
+			if i+g > n || slices.Contains(row.Springs[i:i+g], '.') || (i+g < n && row.Springs[i+g] == '#') {
+				continue
+			}
+			ways[i][j] += ways[i+g+1][j+1]
+		}
+	}
+
+	return ways[0][0]
+}
+
+func ReferencePartA(path string) int {
+	rows, err := parseInput(path)
+	utils.CheckError(err)
+
+	sum := 0
+	for _, row := range rows {
+		sum += referenceCountBruteForce(row)
+	}
+
+	return sum
+}
+
+func ReferencePartB(path string) int {
+	rows, err := parseInput(path)
+	utils.CheckError(err)
+
+	sum := 0
+	for _, row := range rows {
+		unfolded := Row{Springs: row.Springs, Groups: row.Groups}
+		for i := 1; i < 5; i++ {
+			unfolded.Springs = append(append(slices.Clone(unfolded.Springs), '?'), row.Springs...)
+			unfolded.Groups = append(slices.Clone(unfolded.Groups), row.Groups...)
+		}
+
+		sum += referenceCountTable(unfolded)
+	}
+
+	return sum
+}
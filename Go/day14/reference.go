@@ -0,0 +1,75 @@
+//go:build reference
+
+package day14
+
+import (
+	"aoc/utils"
+	"slices"
+)
+
+// These roll rocks a single step at a time and remember every state seen by scanning a list,
+// rather than rolling each rock all the way at once and looking states up in a map.
+
+// Tilts the dish by repeatedly moving any rock with space in front of it one step, until none
+// can move.
+func referenceTilt(dish utils.Grid[byte], dir utils.Point) {
+	for moved := true; moved; {
+		moved = false
+		posIt := dish.Positions()
+		for posIt.Next() {
+			cur := posIt.Current()
+			next := cur.Add(dir)
+			if dish.GetCopy(cur) == 'O' && dish.Contains(next) && dish.GetCopy(next) == '.' {
+				dish.Set(cur, '.')
+				dish.Set(next, 'O')
+				moved = true
+			}
+		}
+	}
+}
+
+// Gets the load on the north support beams
+func referenceLoad(dish utils.Grid[byte]) int {
+	load := 0
+	posIt := dish.Positions()
+	for posIt.Next() {
+		if cur := posIt.Current(); dish.GetCopy(cur) == 'O' {
+			load += dish.Height() - cur.Y
+		}
+	}
+
+	return load
+}
+
+func ReferencePartA(path string) int {
+	dish, err := parseInput(path)
+	utils.CheckError(err)
+
+	referenceTilt(dish, utils.UP)
+	return referenceLoad(dish)
+}
+
+// Runs cycles until the dish repeats a state it's been in before.  From then on, it goes round
+// the same states forever, so we can tell which one it's in after any number of cycles.
+func ReferencePartB(path string) int {
+	const cycles = 1000000000
+
+	dish, err := parseInput(path)
+	utils.CheckError(err)
+
+	// history[i] is the dish after i cycles
+	history := []utils.Grid[byte]{utils.GridFromSlice(slices.Clone(dish.Slice), dish.Width())}
+	for {
+		for _, dir := range []utils.Point{utils.UP, utils.LEFT, utils.DOWN, utils.RIGHT} {
+			referenceTilt(dish, dir)
+		}
+
+		first := slices.IndexFunc(history, func(g utils.Grid[byte]) bool { return slices.Equal(g.Slice, dish.Slice) })
+		if first != -1 {
+			period := len(history) - first
+			return referenceLoad(history[first+(cycles-first)%period])
+		}
+
+		history = append(history, utils.GridFromSlice(slices.Clone(dish.Slice), dish.Width()))
+	}
+}
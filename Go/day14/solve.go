@@ -133,18 +133,22 @@ func calculateNorthernSupportLoad(dish utils.Grid[byte]) int {
 	return sum
 }
 
-// Performs a cycle as defined by part 2
-func performCycle(dish utils.Grid[byte]) {
+// Performs a cycle as defined by part 2, showing each tilt to the watcher (which may be nil)
+func performCycle(dish utils.Grid[byte], dw *dishWatcher) {
 	rollNorth(dish)
+	dw.frame(dish, "tilt north")
 	rollWest(dish)
+	dw.frame(dish, "tilt west")
 	rollSouth(dish)
+	dw.frame(dish, "tilt south")
 	rollEast(dish)
+	dw.frame(dish, "tilt east")
 }
 
 // Performs "n" cycles (as defined by part 2) on the given grid.  The trick is to cut down on the
 // number of iterations by detecting cycles where the grid repeatedly moves between the same states,
 // and to use those cycles to skip a bunch of iterations.
-func performNCycles(dish utils.Grid[byte], iterations int) {
+func performNCycles(dish utils.Grid[byte], iterations int, dw *dishWatcher) {
 	stateMap := map[string]int{}
 	stateMap[rocksToString(dish)] = 0
 
@@ -152,7 +156,7 @@ func performNCycles(dish utils.Grid[byte], iterations int) {
 	curIter := 0
 	period := 0
 	for i := 1; i <= iterations; i++ {
-		performCycle(dish)
+		performCycle(dish, dw)
 
 		state := rocksToString(dish)
 
@@ -185,7 +189,7 @@ func performNCycles(dish utils.Grid[byte], iterations int) {
 
 		// Perform remaining cycles
 		for i := 0; i < remainingIters; i++ {
-			performCycle(dish)
+			performCycle(dish, dw)
 		}
 	}
 }
@@ -206,7 +210,7 @@ func PartB(path string) int {
 	utils.CheckError(err)
 
 	// Perform cycles
-	performNCycles(dish, 1000000000)
+	performNCycles(dish, 1000000000, nil)
 
 	// Calculate and return support load
 	return calculateNorthernSupportLoad(dish)
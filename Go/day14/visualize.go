@@ -0,0 +1,67 @@
+package day14
+
+import (
+	"aoc/utils"
+	"aoc/utils/vis"
+)
+
+// Reports the dish to an observer after each tilt, showing just the rocks that moved.  A nil
+// *dishWatcher is valid, and does nothing.
+type dishWatcher struct {
+	obs vis.Observer
+	// The dish as of the last frame
+	prev []byte
+	// Rocks drawn as moving in the last frame, which need setting back to normal in the next one
+	moved []utils.Point
+}
+
+// Creates a watcher reporting to the given observer, or nil if the observer is nil.
+func newDishWatcher(dish utils.Grid[byte], obs vis.Observer) *dishWatcher {
+	if obs == nil {
+		return nil
+	}
+
+	obs.Begin(dish)
+	return &dishWatcher{obs: obs, prev: append([]byte{}, dish.Slice...)}
+}
+
+// Sends a frame of everything that changed since the last one.
+func (dw *dishWatcher) frame(dish utils.Grid[byte], label string) {
+	if dw == nil {
+		return
+	}
+
+	var cells []vis.Cell
+	for _, p := range dw.moved {
+		cells = append(cells, vis.Cell{Pos: p, Char: dish.GetCopy(p)})
+	}
+
+	dw.moved = dw.moved[:0]
+	for i, b := range dish.Slice {
+		if b == dw.prev[i] {
+			continue
+		}
+
+		p := dish.PosFromIndex(i)
+		if b == 'O' {
+			cells = append(cells, vis.Cell{Pos: p, Char: b, Style: vis.Active})
+			dw.moved = append(dw.moved, p)
+		} else {
+			cells = append(cells, vis.Cell{Pos: p, Char: b})
+		}
+	}
+	copy(dw.prev, dish.Slice)
+
+	dw.obs.Frame(vis.Frame{Label: label, Cells: cells})
+}
+
+// Runs part B's spin cycles on the given input, showing each tilt to the observer.
+func Visualize(path string, obs vis.Observer) error {
+	dish, err := parseInput(path)
+	if err != nil {
+		return err
+	}
+
+	performNCycles(dish, 1000000000, newDishWatcher(dish, obs))
+	return nil
+}
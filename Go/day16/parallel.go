@@ -0,0 +1,118 @@
+package day16
+
+import (
+	"aoc/utils"
+	"sync"
+)
+
+// A point on the edge of the grid where a beam can enter, and the direction it's travelling in.
+type Entry struct {
+	Position  utils.Point
+	Direction utils.Point
+}
+
+// Gets every possible entry point, in the same order the sequential PartB tries them.  This order
+// breaks ties between entries energizing the same number of tiles.
+func getEntries(grid utils.Grid[byte]) []Entry {
+	var entries []Entry
+	for x := 0; x < grid.Width(); x++ {
+		entries = append(entries, Entry{utils.Point{X: x, Y: 0}, utils.DOWN})
+		entries = append(entries, Entry{utils.Point{X: x, Y: grid.Height() - 1}, utils.UP})
+	}
+	for y := 0; y < grid.Height(); y++ {
+		entries = append(entries, Entry{utils.Point{X: 0, Y: y}, utils.RIGHT})
+		entries = append(entries, Entry{utils.Point{X: grid.Width() - 1, Y: y}, utils.LEFT})
+	}
+
+	return entries
+}
+
+// Gets the directions a beam leaves a tile in, given the direction it entered in.
+func getOutgoingDirs(tile byte, dir utils.Point) []utils.Point {
+	switch tile {
+	case '.':
+		return []utils.Point{dir}
+	case '|':
+		if dir == utils.LEFT || dir == utils.RIGHT {
+			return []utils.Point{utils.UP, utils.DOWN}
+		}
+		return []utils.Point{dir}
+	case '-':
+		if dir == utils.UP || dir == utils.DOWN {
+			return []utils.Point{utils.LEFT, utils.RIGHT}
+		}
+		return []utils.Point{dir}
+	case '\\':
+		// Swaps X and Y
+		return []utils.Point{{X: dir.Y, Y: dir.X}}
+	case '/':
+		return []utils.Point{{X: -dir.Y, Y: -dir.X}}
+	default:
+		panic("Unsupported grid value")
+	}
+}
+
+// Tries every entry point using the given number of workers, returning the entry which energizes
+// the most tiles along with that count.  Ties go to the entry the sequential PartB would find
+// first, so the result doesn't depend on scheduling.
+func findBestEntry(grid utils.Grid[byte], workers int) (Entry, int) {
+	entries := getEntries(grid)
+	if len(entries) == 0 {
+		return Entry{}, 0
+	}
+	counts := make([]int, len(entries))
+	sim := NewBeamSimulator(grid)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < max(workers, 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			// The simulator is safe to share, and only makes workers wait for each other when they
+			// need the reach of the same component; each worker reuses its own bitset for every
+			// entry it handles
+			energized := utils.NewBitset(len(grid.Slice))
+			for i := range jobs {
+				sim.Energize(entries[i], energized)
+				counts[i] = energized.Count()
+			}
+		}()
+	}
+
+	for i := range entries {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	best := 0
+	for i, c := range counts {
+		if c > counts[best] {
+			best = i
+		}
+	}
+
+	return entries[best], counts[best]
+}
+
+// Finds the entry point which energizes the most tiles, and how many it energizes, using the given
+// number of workers.
+func BestEntry(path string, workers int) (Entry, int, error) {
+	grid, err := parseInput(path)
+	if err != nil {
+		return Entry{}, 0, err
+	}
+
+	entry, count := findBestEntry(grid, workers)
+	return entry, count, nil
+}
+
+// Equivalent to PartB, but spreads the entry points across the given number of workers.
+func PartBParallel(path string, workers int) int {
+	_, count, err := BestEntry(path, workers)
+	utils.CheckError(err)
+
+	return count
+}
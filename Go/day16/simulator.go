@@ -0,0 +1,260 @@
+package day16
+
+import (
+	"aoc/utils"
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// Simulates beams without recursion, sharing work between entry points.
+//
+// A beam which hits a splitter side-on always continues the same way (out both ends of the
+// splitter), no matter where it came from.  So we treat each splitter as a node in a graph, whose
+// edges are the straight-line (give or take mirrors) segments from its two ends to the next
+// splitter they hit side-on.  The tiles energized by a beam hitting a splitter are then the tiles on
+// every segment reachable from that splitter in the graph.  That graph can have cycles, so we
+// collapse each strongly connected component to a single node; every splitter in a component
+// reaches the same set of tiles, and the components form a DAG.
+//
+// The set of tiles reachable from a component is cached as a bitset the first time a beam hits it,
+// and reused by every later beam which hits it.  Only components hit directly by a beam are cached,
+// since large grids can have far too many components to give them all a bitset.
+//
+// A BeamSimulator is safe to use from multiple goroutines.
+type BeamSimulator struct {
+	grid utils.Grid[byte]
+	// Component of the splitter at each grid index, or -1 if there's no splitter there
+	compAt []int
+	// Grid indices of the tiles on each component's own segments
+	compTiles [][]int
+	// Components each component's segments lead to
+	compSuccessors [][]int
+
+	// Makes sure each component's reach is only worked out once, however many beams hit it at once
+	reachOnce []sync.Once
+	// Tiles energized by a beam which hits a splitter in each component, or nil if not cached yet.
+	// Stored atomically, so a search can use the reach of any component found before it.
+	reach []atomic.Pointer[utils.Bitset]
+}
+
+// Follows a beam until it hits a splitter side-on, leaves the grid, or goes round in a loop,
+// calling mark with the grid index of every tile it passes through (including the splitter).
+// Returns the grid index of the splitter hit, or -1 if there wasn't one.
+func (sim *BeamSimulator) traceSegment(pos, dir utils.Point, mark func(int)) int {
+	// Away from side-on splitters, every (position, direction) state has only one state that can
+	// lead to it.  So two beams can never merge, and if a beam goes round in a loop, it must come
+	// back to where it started.
+	startPos, startDir := pos, dir
+	for sim.grid.Contains(pos) {
+		idx := pos.ToIndex(sim.grid.Width())
+		mark(idx)
+
+		dirs := getOutgoingDirs(sim.grid.GetCopy(pos), dir)
+		if len(dirs) > 1 {
+			return idx
+		}
+
+		dir = dirs[0]
+		pos = pos.Add(dir)
+		if pos == startPos && dir == startDir {
+			break
+		}
+	}
+
+	return -1
+}
+
+// Builds the splitter graph for the grid and condenses it into components.
+func NewBeamSimulator(grid utils.Grid[byte]) *BeamSimulator {
+	sim := &BeamSimulator{grid: grid}
+
+	nodeAt := make([]int, len(grid.Slice))
+	var positions []utils.Point
+	for i, b := range grid.Slice {
+		nodeAt[i] = -1
+		if b == '|' || b == '-' {
+			nodeAt[i] = len(positions)
+			positions = append(positions, grid.PosFromIndex(i))
+		}
+	}
+
+	// The tiles on each node's own segments, and the nodes its segments lead to
+	tiles := make([][]int, len(positions))
+	successors := make([][]int, len(positions))
+	for n, pos := range positions {
+		tiles[n] = []int{pos.ToIndex(grid.Width())}
+		mark := func(idx int) { tiles[n] = append(tiles[n], idx) }
+
+		// The outgoing directions are the same whichever side the beam hits from
+		dirs := getOutgoingDirs(grid.GetCopy(pos), utils.RIGHT)
+		if len(dirs) == 1 {
+			dirs = getOutgoingDirs(grid.GetCopy(pos), utils.DOWN)
+		}
+		for _, d := range dirs {
+			if next := sim.traceSegment(pos.Add(d), d, mark); next != -1 && !slices.Contains(successors[n], nodeAt[next]) {
+				successors[n] = append(successors[n], nodeAt[next])
+			}
+		}
+	}
+
+	comp := findComponents(successors)
+	numComps := 0
+	for _, c := range comp {
+		numComps = max(numComps, c+1)
+	}
+
+	sim.compAt = make([]int, len(grid.Slice))
+	for i, n := range nodeAt {
+		sim.compAt[i] = -1
+		if n != -1 {
+			sim.compAt[i] = comp[n]
+		}
+	}
+
+	sim.compTiles = make([][]int, numComps)
+	sim.compSuccessors = make([][]int, numComps)
+	for n := range positions {
+		c := comp[n]
+		sim.compTiles[c] = append(sim.compTiles[c], tiles[n]...)
+		for _, next := range successors[n] {
+			if comp[next] != c && !slices.Contains(sim.compSuccessors[c], comp[next]) {
+				sim.compSuccessors[c] = append(sim.compSuccessors[c], comp[next])
+			}
+		}
+	}
+
+	sim.reachOnce = make([]sync.Once, numComps)
+	sim.reach = make([]atomic.Pointer[utils.Bitset], numComps)
+
+	return sim
+}
+
+// Finds the strongly connected components of a graph via Tarjan's algorithm, using an explicit
+// stack so deep graphs can't overflow the call stack.  Returns the component index of each node.
+func findComponents(successors [][]int) []int {
+	n := len(successors)
+	index := make([]int, n)
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	comp := make([]int, n)
+	for i := range index {
+		index[i] = -1
+	}
+
+	type frame struct {
+		node int
+		next int
+	}
+
+	var stack []int
+	counter, components := 0, 0
+	visit := func(v int, calls []frame) []frame {
+		index[v], low[v] = counter, counter
+		counter++
+		stack = append(stack, v)
+		onStack[v] = true
+		return append(calls, frame{v, 0})
+	}
+
+	for root := 0; root < n; root++ {
+		if index[root] != -1 {
+			continue
+		}
+
+		calls := visit(root, nil)
+		for len(calls) > 0 {
+			f := &calls[len(calls)-1]
+			if f.next < len(successors[f.node]) {
+				w := successors[f.node][f.next]
+				f.next++
+				if index[w] == -1 {
+					calls = visit(w, calls)
+				} else if onStack[w] {
+					low[f.node] = min(low[f.node], index[w])
+				}
+				continue
+			}
+
+			v := f.node
+			calls = calls[:len(calls)-1]
+			if len(calls) > 0 {
+				parent := calls[len(calls)-1].node
+				low[parent] = min(low[parent], low[v])
+			}
+
+			// If v is the root of a component, its members are on the stack above it
+			if low[v] == index[v] {
+				for {
+					m := stack[len(stack)-1]
+					stack = stack[:len(stack)-1]
+					onStack[m] = false
+					comp[m] = components
+					if m == v {
+						break
+					}
+				}
+				components++
+			}
+		}
+	}
+
+	return comp
+}
+
+// Gets the tiles energized by a beam hitting a splitter in the given component, computing and
+// caching them if need be.  Searches for different components run concurrently; only a second beam
+// hitting the same component waits for the first to finish.
+func (sim *BeamSimulator) getReach(c int) utils.Bitset {
+	sim.reachOnce[c].Do(func() {
+		reach := sim.findReach(c)
+		sim.reach[c].Store(&reach)
+	})
+
+	return *sim.reach[c].Load()
+}
+
+// Searches the components reachable from the given one for the tiles on their segments.
+func (sim *BeamSimulator) findReach(c int) utils.Bitset {
+	reach := utils.NewBitset(len(sim.grid.Slice))
+	visited := utils.NewBitset(len(sim.compTiles))
+	visited.Set(c)
+	stack := []int{c}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		// No need to look further down than a component we already know the reach of
+		if known := sim.reach[cur].Load(); known != nil {
+			reach.Union(*known)
+			continue
+		}
+
+		for _, idx := range sim.compTiles[cur] {
+			reach.Set(idx)
+		}
+		for _, next := range sim.compSuccessors[cur] {
+			if !visited.TestAndSet(next) {
+				stack = append(stack, next)
+			}
+		}
+	}
+
+	return reach
+}
+
+// Fills energizedTiles (which is cleared first) with the grid index of every tile energized by a
+// beam from the given entry.
+func (sim *BeamSimulator) Energize(entry Entry, energizedTiles utils.Bitset) {
+	energizedTiles.Clear()
+	if idx := sim.traceSegment(entry.Position, entry.Direction, energizedTiles.Set); idx != -1 {
+		energizedTiles.Union(sim.getReach(sim.compAt[idx]))
+	}
+}
+
+// Gets the number of tiles energized by a beam from the given entry.
+func (sim *BeamSimulator) CountEnergized(entry Entry) int {
+	energized := utils.NewBitset(len(sim.grid.Slice))
+	sim.Energize(entry, energized)
+	return energized.Count()
+}
@@ -0,0 +1,159 @@
+package day16
+
+import (
+	"aoc/utils"
+	"math/rand"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Builds a grid from its rows
+func gridFromRows(rows ...string) utils.Grid[byte] {
+	grid, err := utils.ReadGridFromBytes(strings.NewReader(strings.Join(rows, "\n")+"\n"), func(b byte, p utils.Point) (byte, error) { return b, nil })
+	utils.CheckError(err)
+	return grid
+}
+
+// Counts the tiles energized from the given entry by following every beam a tile at a time
+func bruteForceEnergized(grid utils.Grid[byte], entry Entry) int {
+	type state struct{ Pos, Dir utils.Point }
+	seen := map[state]bool{}
+	tiles := map[utils.Point]bool{}
+	stack := []state{{entry.Position, entry.Direction}}
+	for len(stack) > 0 {
+		cur := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !grid.Contains(cur.Pos) || seen[cur] {
+			continue
+		}
+		seen[cur] = true
+		tiles[cur.Pos] = true
+
+		for _, d := range getOutgoingDirs(grid.GetCopy(cur.Pos), cur.Dir) {
+			stack = append(stack, state{cur.Pos.Add(d), d})
+		}
+	}
+
+	return len(tiles)
+}
+
+// Checks the simulator against brute force for every entry of the grid
+func checkAllEntries(t *testing.T, name string, grid utils.Grid[byte]) {
+	t.Helper()
+
+	sim := NewBeamSimulator(grid)
+	for _, entry := range getEntries(grid) {
+		if got, want := sim.CountEnergized(entry), bruteForceEnergized(grid, entry); got != want {
+			t.Errorf("%s: entry %v energized %d tiles, want %d", name, entry, got, want)
+		}
+	}
+}
+
+// Gets a random grid of mirrors and splitters
+func randomGrid(rng *rand.Rand, width, height int) utils.Grid[byte] {
+	rows := make([]string, height)
+	for y := range rows {
+		row := make([]byte, width)
+		for x := range row {
+			row[x] = "....|-/\\"[rng.Intn(8)]
+		}
+		rows[y] = string(row)
+	}
+
+	return gridFromRows(rows...)
+}
+
+func TestBeamSimulatorCycle(t *testing.T) {
+	// The beam up from the '|' is turned by the mirrors into the side of the '-', which sends a beam
+	// left back into the side of the '|'
+	grid := gridFromRows(
+		"../..\\.",
+		".......",
+		"..|..-.",
+		".......",
+		".......",
+	)
+
+	sim := NewBeamSimulator(grid)
+	first, second := sim.compAt[utils.Point{X: 2, Y: 2}.ToIndex(grid.Width())], sim.compAt[utils.Point{X: 5, Y: 2}.ToIndex(grid.Width())]
+	if first == -1 || first != second {
+		t.Errorf("splitters are in components %d and %d, expected the same one", first, second)
+	}
+
+	// Hitting either splitter energizes the whole loop, along with the beams out of it
+	for _, entry := range []Entry{
+		{utils.Point{X: 0, Y: 2}, utils.RIGHT},
+		{utils.Point{X: 5, Y: 4}, utils.UP},
+	} {
+		if got := sim.CountEnergized(entry); got != 15 {
+			t.Errorf("entry %v energized %d tiles, want 15", entry, got)
+		}
+	}
+
+	checkAllEntries(t, "cycle", grid)
+}
+
+func TestBeamSimulatorMatchesBruteForce(t *testing.T) {
+	sample, err := parseInput("../inputs/day16_sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkAllEntries(t, "sample", sample)
+
+	// Enough splitters that most grids have cycles, and some have several
+	rng := rand.New(rand.NewSource(1))
+	cycles := 0
+	for i := 0; i < 200; i++ {
+		grid := randomGrid(rng, 3+rng.Intn(10), 3+rng.Intn(10))
+		checkAllEntries(t, string(grid.Slice), grid)
+
+		sim := NewBeamSimulator(grid)
+		splitters := 0
+		for _, c := range sim.compAt {
+			if c != -1 {
+				splitters++
+			}
+		}
+		if len(sim.compTiles) < splitters {
+			cycles++
+		}
+	}
+
+	if cycles == 0 {
+		t.Error("no random grid had a cycle of splitters")
+	}
+}
+
+// Energizes every entry from many goroutines at once, on a simulator with nothing cached yet.  Run
+// with -race to check the cache is shared safely.
+func TestBeamSimulatorConcurrent(t *testing.T) {
+	grid := randomGrid(rand.New(rand.NewSource(2)), 40, 40)
+	entries := getEntries(grid)
+	want := make([]int, len(entries))
+	for i, entry := range entries {
+		want[i] = bruteForceEnergized(grid, entry)
+	}
+
+	sim := NewBeamSimulator(grid)
+	const goroutines = 32
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+
+			// Each goroutine starts at a different entry, so they race to fill different parts of
+			// the cache
+			energized := utils.NewBitset(len(grid.Slice))
+			for j := range entries {
+				i := (j + g*len(entries)/goroutines) % len(entries)
+				sim.Energize(entries[i], energized)
+				if got := energized.Count(); got != want[i] {
+					t.Errorf("entry %v energized %d tiles, want %d", entries[i], got, want[i])
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,42 @@
+package day16
+
+import (
+	"aoc/utils"
+	"aoc/utils/testutil"
+	"runtime"
+	"testing"
+)
+
+func BenchmarkDay(b *testing.B) {
+	testutil.BenchmarkDay(b, 16, func(path string) error { _, err := parseInput(path); return err }, PartA, PartB)
+}
+
+func BenchmarkPartBParallel(b *testing.B) {
+	path := testutil.RealInput(b, 16)
+	for i := 0; i < b.N; i++ {
+		PartBParallel(path, runtime.NumCPU())
+	}
+}
+
+func TestPartBParallel(t *testing.T) {
+	path := "../inputs/day16_sample.txt"
+	want := PartB(path)
+	if want != 51 {
+		t.Fatalf("PartB gave %d, want 51", want)
+	}
+
+	for _, workers := range []int{0, 1, 2, 8} {
+		if got := PartBParallel(path, workers); got != want {
+			t.Errorf("%d workers: got %d, want %d", workers, got, want)
+		}
+
+		// Ties are broken the same way whatever the number of workers
+		entry, count, err := BestEntry(path, workers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if wantEntry := (Entry{utils.Point{X: 3, Y: 0}, utils.DOWN}); entry != wantEntry || count != want {
+			t.Errorf("%d workers: got best entry %v energizing %d, want %v energizing %d", workers, entry, count, wantEntry, want)
+		}
+	}
+}
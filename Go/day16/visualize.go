@@ -0,0 +1,56 @@
+package day16
+
+import (
+	"aoc/utils"
+	"aoc/utils/vis"
+	"fmt"
+	"slices"
+)
+
+// Spreads the part A beam through the given input one step at a time, showing each step to the
+// observer; the tiles the beams are on are active, and tiles they've already passed through are
+// highlighted.
+func Visualize(path string, obs vis.Observer) error {
+	grid, err := parseInput(path)
+	if err != nil {
+		return err
+	}
+	obs.Begin(grid)
+
+	dirs := utils.CARDINAL_DIRS_CLOCKWISE
+	visited := utils.NewBitset(len(grid.Slice) * len(dirs))
+
+	heads := []Entry{{utils.Point{X: 0, Y: 0}, utils.RIGHT}}
+	var prevHeads []utils.Point
+	for step := 0; ; step++ {
+		// Drop beams that have left the grid, or are following a path already taken
+		var live []Entry
+		for _, h := range heads {
+			if grid.Contains(h.Position) && !visited.TestAndSet(h.Position.ToIndex(grid.Width())*len(dirs)+slices.Index(dirs, h.Direction)) {
+				live = append(live, h)
+			}
+		}
+
+		var cells []vis.Cell
+		for _, p := range prevHeads {
+			cells = append(cells, vis.Cell{Pos: p, Char: grid.GetCopy(p), Style: vis.Highlight})
+		}
+		prevHeads = prevHeads[:0]
+		for _, h := range live {
+			cells = append(cells, vis.Cell{Pos: h.Position, Char: grid.GetCopy(h.Position), Style: vis.Active})
+			prevHeads = append(prevHeads, h.Position)
+		}
+		obs.Frame(vis.Frame{Label: fmt.Sprintf("step %d, %d beams", step, len(live)), Cells: cells})
+
+		if len(live) == 0 {
+			return nil
+		}
+
+		heads = heads[:0]
+		for _, h := range live {
+			for _, d := range getOutgoingDirs(grid.GetCopy(h.Position), h.Direction) {
+				heads = append(heads, Entry{h.Position.Add(d), d})
+			}
+		}
+	}
+}
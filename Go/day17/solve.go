@@ -2,6 +2,8 @@ package day17
 
 import (
 	"aoc/utils"
+	"aoc/utils/vis"
+	"fmt"
 	"math"
 	"os"
 
@@ -31,9 +33,21 @@ var neighbors = map[utils.Point][]utils.Point{
 	utils.LEFT:  {utils.DOWN, utils.LEFT, utils.UP},
 }
 
-func shortestPath(grid utils.Grid[int], start, end utils.Point, minDist, maxDist int) (int, bool) {
+// Finds the cost of the cheapest path from start to end, moving between minDist and maxDist tiles
+// in a straight line at a time.  If obs is non-nil, the positions searched are shown to it as a
+// frame for each estimated cost, and the path found as a final frame.
+func shortestPath(grid utils.Grid[int], start, end utils.Point, minDist, maxDist int, obs vis.Observer) (int, bool) {
 	heap := lane.NewMinPriorityQueue[AStarItem, int]()
 
+	// Only tracked for the observer: the state each state was reached from, and the positions
+	// searched since the last frame
+	var parents map[State]State
+	var searched []vis.Cell
+	frameF := 0
+	if obs != nil {
+		parents = map[State]State{}
+	}
+
 	initState := State{Position: start, Direction: utils.RIGHT, Travel: 1}
 	initState2 := State{Position: start, Direction: utils.DOWN, Travel: 1}
 	dist := map[State]int{initState: 0, initState2: 0}
@@ -51,8 +65,21 @@ func shortestPath(grid utils.Grid[int], start, end utils.Point, minDist, maxDist
 			panic("Remove from priority queue failed.")
 		}
 
+		if obs != nil {
+			if item.F != frameF && len(searched) > 0 {
+				obs.Frame(vis.Frame{Label: fmt.Sprintf("searching estimated cost %d", frameF), Cells: searched})
+				searched = nil
+			}
+			frameF = item.F
+			searched = append(searched, vis.Cell{Pos: item.State.Position, Char: byte('0' + grid.GetCopy(item.State.Position)), Style: vis.Highlight})
+		}
+
 		// We found the shortest path
 		if item.State.Position == end {
+			if obs != nil {
+				obs.Frame(vis.Frame{Label: fmt.Sprintf("searching estimated cost %d", frameF), Cells: searched})
+				sendPath(grid, parents, item.State, item.G, obs)
+			}
 			return item.G, true
 		}
 
@@ -100,6 +127,9 @@ func shortestPath(grid utils.Grid[int], start, end utils.Point, minDist, maxDist
 			}
 			if node.G < d {
 				dist[node.State] = node.G
+				if parents != nil {
+					parents[node.State] = item.State
+				}
 				heap.Push(node, node.Priority())
 			}
 		}
@@ -128,7 +158,7 @@ func PartA(path string) int {
 	grid, err := parseInput(path)
 	utils.CheckError(err)
 
-	sp, ok := shortestPath(grid, utils.Point{X: 0, Y: 0}, utils.Point{X: grid.Width() - 1, Y: grid.Height() - 1}, 0, 3)
+	sp, ok := shortestPath(grid, utils.Point{X: 0, Y: 0}, utils.Point{X: grid.Width() - 1, Y: grid.Height() - 1}, 0, 3, nil)
 	if !ok {
 		panic("No shortest path found")
 	}
@@ -140,7 +170,7 @@ func PartB(path string) int {
 	grid, err := parseInput(path)
 	utils.CheckError(err)
 
-	sp, ok := shortestPath(grid, utils.Point{X: 0, Y: 0}, utils.Point{X: grid.Width() - 1, Y: grid.Height() - 1}, 4, 10)
+	sp, ok := shortestPath(grid, utils.Point{X: 0, Y: 0}, utils.Point{X: grid.Width() - 1, Y: grid.Height() - 1}, 4, 10, nil)
 	if !ok {
 		panic("No shortest path found")
 	}
@@ -0,0 +1,10 @@
+package day17
+
+import (
+	"aoc/utils/testutil"
+	"testing"
+)
+
+func BenchmarkDay(b *testing.B) {
+	testutil.BenchmarkDay(b, 17, func(path string) error { _, err := parseInput(path); return err }, PartA, PartB)
+}
@@ -0,0 +1,44 @@
+package day17
+
+import (
+	"aoc/utils"
+	"aoc/utils/vis"
+	"errors"
+	"fmt"
+)
+
+// Gets the grid of heat losses as digits, for drawing
+func toDigits(grid utils.Grid[int]) utils.Grid[byte] {
+	digits := utils.GridFromDimensions[byte](grid.Width(), grid.Height())
+	for i, v := range grid.Slice {
+		digits.Slice[i] = byte('0' + v)
+	}
+
+	return digits
+}
+
+// Shows the path ending in the given state to the observer, by following parents back to the start.
+func sendPath(grid utils.Grid[int], parents map[State]State, end State, cost int, obs vis.Observer) {
+	var cells []vis.Cell
+	for state, ok := end, true; ok; state, ok = parents[state] {
+		cells = append(cells, vis.Cell{Pos: state.Position, Char: byte('0' + grid.GetCopy(state.Position)), Style: vis.Marked})
+	}
+
+	obs.Frame(vis.Frame{Label: fmt.Sprintf("found path with heat loss %d", cost), Cells: cells})
+}
+
+// Runs the part B search on the given input, showing the search and the path it finds to the
+// observer.
+func Visualize(path string, obs vis.Observer) error {
+	grid, err := parseInput(path)
+	if err != nil {
+		return err
+	}
+
+	obs.Begin(toDigits(grid))
+	if _, ok := shortestPath(grid, utils.Point{X: 0, Y: 0}, utils.Point{X: grid.Width() - 1, Y: grid.Height() - 1}, 4, 10, obs); !ok {
+		return errors.New("no path found")
+	}
+
+	return nil
+}
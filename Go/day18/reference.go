@@ -0,0 +1,102 @@
+//go:build reference
+
+package day18
+
+import (
+	"aoc/utils"
+	"slices"
+)
+
+// These flood fill the outside of the trench on a (compressed) grid, where the real solution
+// works out the area from the trench's corners with the shoelace formula and Pick's theorem.
+
+// Gets the sorted distinct values in the set
+func sortedKeys(set map[int]bool) []int {
+	var keys []int
+	for k := range set {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// Counts the cubes dug by marking the trench on a grid and flood filling the outside.
+//
+// The distances in part 2 are far too big to use a grid of single cubes, so each row and column
+// of the grid covers a whole range of cubes instead.  The ranges start at each x (or y) the
+// trench turns at and the one just after, so the trench itself lies along rows and columns one
+// cube wide, and nothing changes within any grid cell.  There's an extra range on each side,
+// so the flood fill can get all the way round.
+func referenceArea(plan []Instruction) int {
+	var corners []utils.Point
+	xSet, ySet := map[int]bool{}, map[int]bool{}
+	cur := utils.Point{}
+	for _, instruction := range plan {
+		corners = append(corners, cur)
+		for _, d := range []int{-1, 0, 1, 2} {
+			xSet[cur.X+d] = true
+			ySet[cur.Y+d] = true
+		}
+		cur = cur.Add(utils.Point{X: instruction.Direction.X * instruction.Distance, Y: instruction.Direction.Y * instruction.Distance})
+	}
+	corners = append(corners, cur)
+
+	// Cell (i, j) covers xs[i] <= x < xs[i+1] and ys[j] <= y < ys[j+1]
+	xs, ys := sortedKeys(xSet), sortedKeys(ySet)
+	grid := utils.GridFromDimensions[byte](len(xs)-1, len(ys)-1)
+	toCell := func(p utils.Point) utils.Point {
+		x, _ := slices.BinarySearch(xs, p.X)
+		y, _ := slices.BinarySearch(ys, p.Y)
+		return utils.Point{X: x, Y: y}
+	}
+
+	for i := 0; i+1 < len(corners); i++ {
+		from, to := toCell(corners[i]), toCell(corners[i+1])
+		for x := min(from.X, to.X); x <= max(from.X, to.X); x++ {
+			for y := min(from.Y, to.Y); y <= max(from.Y, to.Y); y++ {
+				grid.Set(utils.Point{X: x, Y: y}, '#')
+			}
+		}
+	}
+
+	// The corner cell is outside everything
+	stack := []utils.Point{{X: 0, Y: 0}}
+	grid.Set(stack[0], '.')
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for _, d := range utils.CARDINAL_DIRS_CLOCKWISE {
+			next := p.Add(d)
+			if grid.Contains(next) && grid.GetCopy(next) == 0 {
+				grid.Set(next, '.')
+				stack = append(stack, next)
+			}
+		}
+	}
+
+	area := 0
+	posIt := grid.Positions()
+	for posIt.Next() {
+		p := posIt.Current()
+		if grid.GetCopy(p) != '.' {
+			area += (xs[p.X+1] - xs[p.X]) * (ys[p.Y+1] - ys[p.Y])
+		}
+	}
+
+	return area
+}
+
+func ReferencePartA(path string) int {
+	plan, err := ReadDigPlan(path, false)
+	utils.CheckError(err)
+
+	return referenceArea(plan)
+}
+
+func ReferencePartB(path string) int {
+	plan, err := ReadDigPlan(path, true)
+	utils.CheckError(err)
+
+	return referenceArea(plan)
+}
@@ -0,0 +1,98 @@
+package day18
+
+import (
+	"aoc/utils"
+	"aoc/utils/geom"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// The largest width or height (in meters) that RenderASCII will draw.  Part 2 plans are millions of
+// meters across, so they must be rendered with RenderSVG instead.
+const MaxASCIISize = 500
+
+// Gets the bounding box of the given vertices.
+func getBounds(vertices []utils.Point) utils.Rectangle {
+	bounds := utils.Rectangle{MinExtent: vertices[0], MaxExtent: vertices[0]}
+	for _, v := range vertices {
+		bounds = bounds.ExpandToContain(v)
+	}
+
+	return bounds
+}
+
+// Draws the lagoon as text, one character per cubic meter; '#' for the trench, '~' for the dug
+// out interior, and '.' for ground that is left alone.  Returns an error if the plan is larger
+// than MaxASCIISize in either dimension.
+func RenderASCII(plan []Instruction) (string, error) {
+	vertices, _ := getVertices(plan)
+	bounds := getBounds(vertices)
+
+	width := bounds.MaxExtent.X - bounds.MinExtent.X + 1
+	height := bounds.MaxExtent.Y - bounds.MinExtent.Y + 1
+	if width > MaxASCIISize || height > MaxASCIISize {
+		return "", fmt.Errorf("plan is %dx%d, which is too large to render as text", width, height)
+	}
+
+	var s strings.Builder
+	for y := bounds.MinExtent.Y; y <= bounds.MaxExtent.Y; y++ {
+		for x := bounds.MinExtent.X; x <= bounds.MaxExtent.X; x++ {
+			switch geom.PointInPolygon(utils.Point{X: x, Y: y}, vertices) {
+			case geom.OnBoundary:
+				s.WriteByte('#')
+			case geom.Inside:
+				s.WriteByte('~')
+			default:
+				s.WriteByte('.')
+			}
+		}
+		s.WriteByte('\n')
+	}
+
+	return s.String(), nil
+}
+
+// Draws the lagoon as an SVG image; the interior is filled, and each edge of the trench is drawn
+// in the colour given by its instruction.  This works for plans of any size, since the image is
+// scaled to fit.
+func RenderSVG(w io.Writer, plan []Instruction) error {
+	vertices, _ := getVertices(plan)
+	bounds := getBounds(vertices)
+
+	// Pad by half a meter on each side so the trench (which is drawn through the center of each
+	// square) isn't clipped
+	width := bounds.MaxExtent.X - bounds.MinExtent.X + 1
+	height := bounds.MaxExtent.Y - bounds.MinExtent.Y + 1
+	_, err := fmt.Fprintf(w, "<svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"%g %g %d %d\" width=\"800\" height=\"%d\">\n",
+		float64(bounds.MinExtent.X)-0.5, float64(bounds.MinExtent.Y)-0.5, width, height, max(1, 800*height/width))
+	if err != nil {
+		return err
+	}
+
+	// Interior of the lagoon
+	var points strings.Builder
+	for i, v := range vertices {
+		if i > 0 {
+			points.WriteByte(' ')
+		}
+		fmt.Fprintf(&points, "%d,%d", v.X, v.Y)
+	}
+	_, err = fmt.Fprintf(w, "  <polygon points=\"%s\" fill=\"#3a6ea5\" fill-opacity=\"0.4\" stroke=\"none\"/>\n", points.String())
+	if err != nil {
+		return err
+	}
+
+	// Trench edges.  The stroke width is in screen pixels rather than meters, so it stays visible
+	// no matter how much the image is scaled down.
+	for i, instruction := range plan {
+		_, err = fmt.Fprintf(w, "  <line x1=\"%d\" y1=\"%d\" x2=\"%d\" y2=\"%d\" stroke=\"%s\" stroke-width=\"2\" stroke-linecap=\"square\" vector-effect=\"non-scaling-stroke\"/>\n",
+			vertices[i].X, vertices[i].Y, vertices[i+1].X, vertices[i+1].Y, instruction.Color)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintln(w, "</svg>")
+	return err
+}
@@ -0,0 +1,74 @@
+package day18
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderASCIISample(t *testing.T) {
+	plan, err := ReadDigPlan("../inputs/day18_sample.txt", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := RenderASCII(plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The trench as drawn in the puzzle, with its interior dug out
+	want := "" +
+		"#######\n" +
+		"#~~~~~#\n" +
+		"###~~~#\n" +
+		"..#~~~#\n" +
+		"..#~~~#\n" +
+		"###~###\n" +
+		"#~~~#..\n" +
+		"##~~###\n" +
+		".#~~~~#\n" +
+		".######\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRenderASCIITooLarge(t *testing.T) {
+	plan := planFromLines(t, "R 600 (#000000)", "D 1 (#000000)", "L 600 (#000000)", "U 1 (#000000)")
+	if _, err := RenderASCII(plan); err == nil {
+		t.Error("expected an error rendering a 601x2 plan")
+	}
+}
+
+func TestRenderSVGSample(t *testing.T) {
+	plan, err := ReadDigPlan("../inputs/day18_sample.txt", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var s strings.Builder
+	if err := RenderSVG(&s, plan); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(s.String(), "\n"), "\n")
+
+	// A header, the interior, one line per instruction, then the closing tag
+	if len(lines) != len(plan)+3 {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), len(plan)+3, s.String())
+	}
+	if want := `<svg xmlns="http://www.w3.org/2000/svg" viewBox="-0.5 -0.5 7 10" width="800" height="1142">`; lines[0] != want {
+		t.Errorf("got header %q, want %q", lines[0], want)
+	}
+	if want := `points="0,0 6,0 6,5 4,5 4,7 6,7 6,9 1,9 1,7 0,7 0,5 2,5 2,2 0,2 0,0"`; !strings.Contains(lines[1], want) {
+		t.Errorf("interior %q doesn't contain %q", lines[1], want)
+	}
+	if want := `<line x1="0" y1="0" x2="6" y2="0" stroke="#70c710"`; !strings.HasPrefix(strings.TrimSpace(lines[2]), want) {
+		t.Errorf("first edge %q doesn't start with %q", lines[2], want)
+	}
+	if want := `<line x1="0" y1="2" x2="0" y2="0" stroke="#7a21e3"`; !strings.HasPrefix(strings.TrimSpace(lines[len(lines)-2]), want) {
+		t.Errorf("last edge %q doesn't start with %q", lines[len(lines)-2], want)
+	}
+	if lines[len(lines)-1] != "</svg>" {
+		t.Errorf("got closing line %q, want \"</svg>\"", lines[len(lines)-1])
+	}
+}
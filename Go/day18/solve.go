@@ -2,150 +2,206 @@ package day18
 
 import (
 	"aoc/utils"
+	"aoc/utils/geom"
 	"bufio"
 	"errors"
+	"fmt"
 	"os"
 	"strconv"
+	"strings"
 )
 
-func parseInput(path string) ([]utils.Point, int, error) {
-	f, err := os.Open(path)
+// A single line of the dig plan
+type Instruction struct {
+	// The direction to dig in
+	Direction utils.Point
+	// The number of meters to dig
+	Distance int
+	// The colour code given with the instruction, including the leading '#'
+	Color string
+	// The line of the input file the instruction came from (1-based)
+	Line int
+}
+
+// Parses a color in the form "(#70c710)", returning it without the parens
+func parseColor(token string) (string, error) {
+	color, ok := strings.CutPrefix(token, "(")
+	if ok {
+		color, ok = strings.CutSuffix(color, ")")
+	}
+	if !ok || len(color) != 7 || color[0] != '#' {
+		return "", fmt.Errorf("invalid color %q", token)
+	}
+	if _, err := strconv.ParseUint(color[1:], 16, 0); err != nil {
+		return "", fmt.Errorf("invalid color %q", token)
+	}
+
+	return color, nil
+}
+
+// Parses an instruction in the form "R 6 (#70c710)", using the direction and distance given directly.
+func parseInstruction(line string) (Instruction, error) {
+	parts := utils.NewStringDelimiterScanner(line, " ")
+
+	dir, err := utils.ReadStringFromScanner(parts)
 	if err != nil {
-		return nil, -1, err
+		return Instruction{}, err
 	}
-	defer f.Close()
 
-	scanner := bufio.NewScanner(f)
-	scanner.Split(bufio.ScanLines)
+	val, err := utils.ReadItemFromScanner(parts, strconv.Atoi)
+	if err != nil {
+		return Instruction{}, err
+	}
 
-	vertices := []utils.Point{{X: 0, Y: 0}}
-	outerPoints := 0
-	for scanner.Scan() {
-		parts := utils.NewStringDelimiterScanner(scanner.Text(), " ")
+	color, err := utils.ReadItemFromScanner(parts, parseColor)
+	if err != nil {
+		return Instruction{}, err
+	}
 
-		dir, err := utils.ReadStringFromScanner(parts)
-		if err != nil {
-			return vertices, outerPoints, err
-		}
+	var direction utils.Point
+	switch dir {
+	case "U":
+		direction = utils.UP
+	case "D":
+		direction = utils.DOWN
+	case "L":
+		direction = utils.LEFT
+	case "R":
+		direction = utils.RIGHT
+	default:
+		return Instruction{}, errors.New("invalid direction type")
+	}
 
-		val, err := utils.ReadItemFromScanner(parts, strconv.Atoi)
-		if err != nil {
-			return vertices, outerPoints, err
-		}
+	return Instruction{Direction: direction, Distance: val, Color: color}, nil
+}
 
-		var direction utils.Point
-		switch dir {
-		case "U":
-			direction = utils.UP
-		case "D":
-			direction = utils.DOWN
-		case "L":
-			direction = utils.LEFT
-		case "R":
-			direction = utils.RIGHT
-		default:
-			return vertices, outerPoints, errors.New("invalid direction type")
-		}
+// Parses an instruction in the form "R 6 (#70c710)", using the part 2 interpretation where the
+// direction and distance are encoded in the hex value.
+func parseHexInstruction(line string) (Instruction, error) {
+	parts := utils.NewStringDelimiterScanner(line, " ")
 
-		last := vertices[len(vertices)-1]
-		vertices = append(vertices, utils.Point{X: last.X + (direction.X * val), Y: last.Y + (direction.Y * val)})
-		outerPoints += val
+	_, err := utils.ReadStringFromScanner(parts)
+	if err != nil {
+		return Instruction{}, err
+	}
+
+	_, err = utils.ReadStringFromScanner(parts)
+	if err != nil {
+		return Instruction{}, err
 	}
 
-	return vertices, outerPoints, nil
+	hex, err := utils.ReadItemFromScanner(parts, parseColor)
+	if err != nil {
+		return Instruction{}, err
+	}
+
+	v, err := strconv.ParseInt(hex[1:len(hex)-1], 16, 0)
+	if err != nil {
+		return Instruction{}, err
+	}
+	val := int(v)
+
+	var direction utils.Point
+	switch hex[len(hex)-1] {
+	case '0':
+		direction = utils.RIGHT
+	case '1':
+		direction = utils.DOWN
+	case '2':
+		direction = utils.LEFT
+	case '3':
+		direction = utils.UP
+	default:
+		return Instruction{}, errors.New("invalid direction type")
+	}
+
+	return Instruction{Direction: direction, Distance: val, Color: hex}, nil
 }
 
-func parseInput2(path string) ([]utils.Point, int, error) {
+// Reads the dig plan from the given file, interpreting each line with the given parser.
+func readPlan(path string, parser func(string) (Instruction, error)) ([]Instruction, error) {
 	f, err := os.Open(path)
 	if err != nil {
-		return nil, -1, err
+		return nil, err
 	}
 	defer f.Close()
 
 	scanner := bufio.NewScanner(f)
 	scanner.Split(bufio.ScanLines)
 
-	vertices := []utils.Point{{X: 0, Y: 0}}
-	outerPoints := 0
-	for scanner.Scan() {
-		parts := utils.NewStringDelimiterScanner(scanner.Text(), " ")
-
-		_, err := utils.ReadStringFromScanner(parts)
+	var plan []Instruction
+	for line := 1; scanner.Scan(); line++ {
+		instruction, err := parser(scanner.Text())
 		if err != nil {
-			return vertices, outerPoints, err
+			return plan, err
 		}
+		instruction.Line = line
 
-		_, err = utils.ReadStringFromScanner(parts)
-		if err != nil {
-			return vertices, outerPoints, err
-		}
+		plan = append(plan, instruction)
+	}
 
-		hex, err := utils.ReadStringFromScanner(parts)
-		if err != nil {
-			return vertices, outerPoints, err
-		}
-		hex = hex[1 : len(hex)-1] // Strip parens
+	return plan, nil
+}
 
-		v, err := strconv.ParseInt(hex[1:len(hex)-1], 16, 0)
-		if err != nil {
-			return vertices, outerPoints, err
-		}
-		val := int(v)
-
-		var direction utils.Point
-		switch hex[len(hex)-1] {
-		case '0':
-			direction = utils.RIGHT
-		case '1':
-			direction = utils.DOWN
-		case '2':
-			direction = utils.LEFT
-		case '3':
-			direction = utils.UP
-		default:
-			return vertices, outerPoints, errors.New("invalid direction type")
-		}
+func parseInput(path string) ([]Instruction, error) {
+	return readPlan(path, parseInstruction)
+}
 
-		last := vertices[len(vertices)-1]
-		vertices = append(vertices, utils.Point{X: last.X + (direction.X * val), Y: last.Y + (direction.Y * val)})
-		outerPoints += val
+func parseInput2(path string) ([]Instruction, error) {
+	return readPlan(path, parseHexInstruction)
+}
+
+// Reads the dig plan from the given file and validates it.  If hexEncoded is true, the part 2
+// interpretation of the colour codes is used.
+//
+// Validation compares every pair of edges, so PartA and PartB skip it and read the plan directly.
+func ReadDigPlan(path string, hexEncoded bool) ([]Instruction, error) {
+	var plan []Instruction
+	var err error
+	if hexEncoded {
+		plan, err = parseInput2(path)
+	} else {
+		plan, err = parseInput(path)
+	}
+	if err != nil {
+		return plan, err
 	}
 
-	return vertices, outerPoints, nil
+	return plan, validateTrench(plan)
 }
 
-func getArea(vertices []utils.Point, boundaryPoints int) int {
-	// Shoelace formula.  This gives us the area of the polygon, but since the integer points are
-	// in the center of each square, we're missing 1/2 sq meter around the edge of the outer points.
-	area := 0
-	for i := 0; i < len(vertices); i++ {
-		prev := i - 1
-		if prev == -1 {
-			prev = len(vertices) - 1
-		}
-		next := (i + 1) % len(vertices)
-
-		area += vertices[i].X * (vertices[prev].Y - vertices[next].Y)
+// Follows the plan from the origin and returns the vertices of the trench, and the number of
+// meters of trench dug (aka the number of points on its boundary).
+func getVertices(plan []Instruction) ([]utils.Point, int) {
+	vertices := []utils.Point{{X: 0, Y: 0}}
+	outerPoints := 0
+	for _, instruction := range plan {
+		last := vertices[len(vertices)-1]
+		vertices = append(vertices, utils.Point{X: last.X + (instruction.Direction.X * instruction.Distance), Y: last.Y + (instruction.Direction.Y * instruction.Distance)})
+		outerPoints += instruction.Distance
 	}
 
-	area = utils.Abs(area) / 2
-
-	// Pick's theorem area formula (rearranged with some algebra)
-	innerPoints := area - boundaryPoints/2 + 1
+	return vertices, outerPoints
+}
 
-	return innerPoints + boundaryPoints
+// Gets the number of cubic meters dug out; both the trench itself and the interior of the lagoon.
+func getArea(vertices []utils.Point, boundaryPoints int) int {
+	// The integer points are in the center of each square, so the points strictly inside the trench
+	// polygon (via the shoelace formula and Pick's theorem) plus the points on the trench gives us
+	// the full area.
+	return geom.InteriorPoints(vertices) + boundaryPoints
 }
 
 func PartA(path string) int {
-	vertices, boundaryPoints, err := parseInput(path)
+	plan, err := parseInput(path)
 	utils.CheckError(err)
 
-	return getArea(vertices, boundaryPoints)
+	return getArea(getVertices(plan))
 }
 
 func PartB(path string) int {
-	vertices, boundaryPoints, err := parseInput2(path)
+	plan, err := parseInput2(path)
 	utils.CheckError(err)
 
-	return getArea(vertices, boundaryPoints)
+	return getArea(getVertices(plan))
 }
@@ -0,0 +1,30 @@
+package day18
+
+import (
+	"aoc/utils/testutil"
+	"testing"
+)
+
+func BenchmarkDay(b *testing.B) {
+	testutil.BenchmarkDay(b, 18, func(path string) error { _, err := parseInput(path); return err }, PartA, PartB)
+}
+
+func TestParseColor(t *testing.T) {
+	for _, line := range []string{"R 6 (#70c710)", "U 2 (#7a21e3)"} {
+		if _, err := parseInstruction(line); err != nil {
+			t.Errorf("%q: %v", line, err)
+		}
+		if _, err := parseHexInstruction(line); err != nil {
+			t.Errorf("%q: %v", line, err)
+		}
+	}
+
+	for _, line := range []string{"R 6 x", "R 6 ()", "R 6 (#70c71)", "R 6 #70c710", "R 6 (#70c7100)", "R 6 (#70g710)", "R 6 (70c7100)"} {
+		if _, err := parseInstruction(line); err == nil {
+			t.Errorf("%q: expected an error", line)
+		}
+		if _, err := parseHexInstruction(line); err == nil {
+			t.Errorf("%q: expected an error", line)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package day18
+
+import (
+	"aoc/utils"
+	"aoc/utils/geom"
+	"errors"
+	"fmt"
+)
+
+// Checks that the dig plan describes a simple closed loop; that is, the trench ends where it
+// started and never crosses or runs back along itself.  The error reports the line of the
+// offending instruction.
+func validateTrench(plan []Instruction) error {
+	if len(plan) == 0 {
+		return errors.New("dig plan is empty")
+	}
+
+	for _, instruction := range plan {
+		if instruction.Distance <= 0 {
+			return fmt.Errorf("line %d: instruction digs %d meters", instruction.Line, instruction.Distance)
+		}
+	}
+
+	// Vertex i is the start of the edge dug by instruction i, and vertex i+1 is its end
+	vertices, _ := getVertices(plan)
+	if vertices[len(vertices)-1] != vertices[0] {
+		last := plan[len(plan)-1]
+		return fmt.Errorf("line %d: trench ends at (%d, %d) rather than returning to its start", last.Line, vertices[len(vertices)-1].X, vertices[len(vertices)-1].Y)
+	}
+
+	for i := range plan {
+		// Edges next to each other share an endpoint by definition, so they only intersect
+		// elsewhere if the trench turns straight back on itself.  Since the trench is closed, the
+		// last edge is next to the first.
+		prev := plan[(i+len(plan)-1)%len(plan)]
+		if len(plan) > 1 && prev.Direction.Add(plan[i].Direction) == (utils.Point{}) {
+			return fmt.Errorf("line %d: trench doubles back along the trench from line %d", plan[i].Line, prev.Line)
+		}
+
+		// Any other edges must not touch at all
+		for j := 0; j < i; j++ {
+			if j == i-1 || (i == len(plan)-1 && j == 0) {
+				continue
+			}
+
+			if geom.SegmentsIntersect(vertices[i], vertices[i+1], vertices[j], vertices[j+1]) {
+				return fmt.Errorf("line %d: trench intersects the trench from line %d", plan[i].Line, plan[j].Line)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,62 @@
+package day18
+
+import (
+	"testing"
+)
+
+// Parses each line as an instruction, numbering them from 1
+func planFromLines(t *testing.T, lines ...string) []Instruction {
+	t.Helper()
+
+	var plan []Instruction
+	for i, line := range lines {
+		instruction, err := parseInstruction(line)
+		if err != nil {
+			t.Fatalf("%q: %v", line, err)
+		}
+		instruction.Line = i + 1
+		plan = append(plan, instruction)
+	}
+
+	return plan
+}
+
+func TestValidateTrenchSample(t *testing.T) {
+	for _, hex := range []bool{false, true} {
+		if _, err := ReadDigPlan("../inputs/day18_sample.txt", hex); err != nil {
+			t.Errorf("hex == %t: %v", hex, err)
+		}
+	}
+}
+
+func TestValidateTrenchErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		lines []string
+		want  string
+	}{
+		{"empty", nil, "dig plan is empty"},
+		{"zero length", []string{"R 2 (#000000)", "D 0 (#000000)", "D 2 (#000000)", "L 2 (#000000)", "U 2 (#000000)"},
+			"line 2: instruction digs 0 meters"},
+		{"negative length", []string{"R 2 (#000000)", "D -2 (#000000)"}, "line 2: instruction digs -2 meters"},
+		{"not closed", []string{"R 2 (#000000)", "D 2 (#000000)", "L 2 (#000000)"},
+			"line 3: trench ends at (0, 2) rather than returning to its start"},
+		{"crosses itself", []string{"R 2 (#000000)", "D 1 (#000000)", "L 1 (#000000)", "U 2 (#000000)", "L 1 (#000000)", "D 1 (#000000)"},
+			"line 4: trench intersects the trench from line 1"},
+		{"touches itself", []string{"R 4 (#000000)", "D 2 (#000000)", "L 2 (#000000)", "U 2 (#000000)", "U 1 (#000000)", "L 2 (#000000)", "D 1 (#000000)"},
+			"line 4: trench intersects the trench from line 1"},
+		{"doubles back", []string{"R 2 (#000000)", "D 2 (#000000)", "U 1 (#000000)", "L 2 (#000000)", "U 1 (#000000)"},
+			"line 3: trench doubles back along the trench from line 2"},
+		{"doubles back at the start", []string{"R 2 (#000000)", "L 2 (#000000)"},
+			"line 1: trench doubles back along the trench from line 2"},
+	}
+
+	for _, test := range tests {
+		err := validateTrench(planFromLines(t, test.lines...))
+		if err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		} else if err.Error() != test.want {
+			t.Errorf("%s: got error %q, want %q", test.name, err, test.want)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package day19
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// A node in a compiled decision tree.  Leaves hold a verdict ("A" or "R"); every other node tests
+// a condition and moves to Pass or Fail depending on the result.
+type DecisionNode struct {
+	Cnd  Condition
+	Pass *DecisionNode
+	Fail *DecisionNode
+	// "A" or "R" for leaves; empty for all other nodes
+	Verdict string
+	// The workflow whose rule this node was compiled from (empty for leaves)
+	Origin string
+}
+
+// Formats the workflow the way the puzzle input does; eg. "px{a<2006:qkq,m>2090:A,rfg}"
+func (wf Workflow) String() string {
+	var s strings.Builder
+	s.WriteString(wf.ID)
+	s.WriteByte('{')
+	for _, r := range wf.Rules {
+		s.WriteString(r.Cnd.String())
+		s.WriteByte(':')
+		s.WriteString(r.Dst)
+		s.WriteByte(',')
+	}
+	s.WriteString(wf.DefaultRule)
+	s.WriteByte('}')
+
+	return s.String()
+}
+
+// Returns whether or not the two trees make the same decisions in the same way.
+func (node *DecisionNode) equals(other *DecisionNode) bool {
+	if node == other {
+		return true
+	}
+	if node.Verdict != "" || other.Verdict != "" {
+		return node.Verdict == other.Verdict
+	}
+
+	return node.Cnd.String() == other.Cnd.String() && node.Pass.equals(other.Pass) && node.Fail.equals(other.Fail)
+}
+
+// Inlines the given workflow (and everything it leads to) into a decision tree, given that only
+// parts within the given boxes can reach it.  Rules which can never fire within those boxes are
+// dropped, and rules whose outcome doesn't depend on their condition are collapsed.
+func compileFlow(workflows map[string]Workflow, flowID string, boxes []ValRange, visiting map[string]bool) (*DecisionNode, error) {
+	if flowID == "A" || flowID == "R" {
+		return &DecisionNode{Verdict: flowID}, nil
+	}
+
+	flow, ok := workflows[flowID]
+	if !ok {
+		return nil, fmt.Errorf("workflow %q does not exist", flowID)
+	}
+	if visiting[flowID] {
+		return nil, fmt.Errorf("workflow %q is part of a cycle", flowID)
+	}
+	visiting[flowID] = true
+	defer delete(visiting, flowID)
+
+	return compileRules(workflows, flow, 0, boxes, visiting)
+}
+
+// Compiles the rules of the given workflow, starting at ruleIdx, into a decision tree.
+func compileRules(workflows map[string]Workflow, flow Workflow, ruleIdx int, boxes []ValRange, visiting map[string]bool) (*DecisionNode, error) {
+	if ruleIdx == len(flow.Rules) {
+		return compileFlow(workflows, flow.DefaultRule, boxes, visiting)
+	}
+
+	rule := flow.Rules[ruleIdx]
+	var trueBoxes, falseBoxes []ValRange
+	for _, box := range boxes {
+		t, f := rule.Cnd.split(box)
+		trueBoxes = append(trueBoxes, t...)
+		falseBoxes = append(falseBoxes, f...)
+	}
+
+	// Rule can never fire given the earlier constraints; skip it
+	if len(trueBoxes) == 0 {
+		return compileRules(workflows, flow, ruleIdx+1, boxes, visiting)
+	}
+
+	// Rule always fires; the remaining rules are unreachable
+	if len(falseBoxes) == 0 {
+		return compileFlow(workflows, rule.Dst, boxes, visiting)
+	}
+
+	pass, err := compileFlow(workflows, rule.Dst, trueBoxes, visiting)
+	if err != nil {
+		return nil, err
+	}
+	fail, err := compileRules(workflows, flow, ruleIdx+1, falseBoxes, visiting)
+	if err != nil {
+		return nil, err
+	}
+
+	// Both outcomes end up making the same decisions, so the condition is irrelevant
+	if pass.equals(fail) {
+		return pass, nil
+	}
+
+	return &DecisionNode{Cnd: rule.Cnd, Pass: pass, Fail: fail, Origin: flow.ID}, nil
+}
+
+// Compiles the workflows into a single decision tree starting at "in", with unreachable rules
+// removed and redundant branches merged.
+func Compile(workflows []Workflow) (*DecisionNode, error) {
+	return compileFlow(genWorkflowMap(workflows), "in", []ValRange{getFullRange(workflows)}, map[string]bool{})
+}
+
+// Runs the part through the decision tree and returns whether it is accepted.
+func (node *DecisionNode) Accepts(part Part) bool {
+	for node.Verdict == "" {
+		if node.Cnd.appliesTo(part) {
+			node = node.Pass
+		} else {
+			node = node.Fail
+		}
+	}
+
+	return node.Verdict == "A"
+}
+
+// Translates the decision tree back into workflows.  Each chain of nodes linked by their Fail
+// branches becomes one workflow, named after the workflow the chain's first rule came from.  The
+// root is always named "in".
+func (node *DecisionNode) ToWorkflows() []Workflow {
+	var workflows []Workflow
+	used := map[string]bool{}
+
+	// Picks a unique name for a workflow, since the same original workflow could have been inlined
+	// in multiple places
+	getName := func(origin string) string {
+		name := origin
+		for i := 2; used[name] || name == "A" || name == "R"; i++ {
+			name = origin + strconv.Itoa(i)
+		}
+		used[name] = true
+
+		return name
+	}
+
+	var emit func(n *DecisionNode, name string)
+	emit = func(n *DecisionNode, name string) {
+		// Reserve a spot so parents come before children in the output
+		idx := len(workflows)
+		workflows = append(workflows, Workflow{ID: name})
+
+		var rules []Rule
+		for ; n.Verdict == ""; n = n.Fail {
+			dst := n.Pass.Verdict
+			if dst == "" {
+				dst = getName(n.Pass.Origin)
+				emit(n.Pass, dst)
+			}
+			rules = append(rules, Rule{Cnd: n.Cnd, Dst: dst})
+		}
+
+		workflows[idx].Rules = rules
+		workflows[idx].DefaultRule = n.Verdict
+	}
+
+	emit(node, getName("in"))
+
+	return workflows
+}
+
+// Checks that the decision tree accepts exactly the same parts as the original workflows do.
+func verifyCompiled(root *DecisionNode, workflows []Workflow, parts []Part) error {
+	wfMap := genWorkflowMap(workflows)
+	for _, part := range parts {
+		if expected := isAccepted(part, wfMap); root.Accepts(part) != expected {
+			return fmt.Errorf("compiled workflows disagree on part %+v; expected accepted == %t", part, expected)
+		}
+	}
+
+	return nil
+}
+
+// Compiles and simplifies the workflows in the given input, and returns them in the puzzle's
+// syntax, one per line.  The result is checked against the original workflows using the parts in
+// the input.
+func SimplifyWorkflows(path string) (string, error) {
+	workflows, parts, err := parseInput(path)
+	if err != nil {
+		return "", err
+	}
+
+	root, err := Compile(workflows)
+	if err != nil {
+		return "", err
+	}
+
+	err = verifyCompiled(root, workflows, parts)
+	if err != nil {
+		return "", err
+	}
+
+	var s strings.Builder
+	for _, wf := range root.ToWorkflows() {
+		s.WriteString(wf.String())
+		s.WriteByte('\n')
+	}
+
+	return s.String(), nil
+}
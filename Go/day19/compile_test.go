@@ -0,0 +1,131 @@
+package day19
+
+import (
+	"strings"
+	"testing"
+)
+
+// Parses each workflow, failing the test on any error
+func mustParseWorkflows(t *testing.T, flows ...string) []Workflow {
+	t.Helper()
+
+	var workflows []Workflow
+	for _, f := range flows {
+		w, err := parseWorkflow(f)
+		if err != nil {
+			t.Fatalf("%s: %v", f, err)
+		}
+		workflows = append(workflows, w)
+	}
+
+	return workflows
+}
+
+// Compiles the workflows and translates them back, giving the result one workflow per line
+func compileToString(t *testing.T, workflows []Workflow) string {
+	t.Helper()
+
+	root, err := Compile(workflows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []string
+	for _, wf := range root.ToWorkflows() {
+		lines = append(lines, wf.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func TestCompileRoundTrip(t *testing.T) {
+	workflows, parts, err := parseInput("../inputs/day19_sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root, err := Compile(workflows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compiled := root.ToWorkflows()
+	if compiled[0].ID != "in" {
+		t.Errorf("first workflow is %q, expected \"in\"", compiled[0].ID)
+	}
+
+	// The compiled workflows must accept the same parts, and the same number of possible parts
+	count, err := countAccepted(getFullRange(compiled), genWorkflowMap(compiled), "in")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 167409079868000; count != want {
+		t.Errorf("compiled workflows accept %d parts, want %d", count, want)
+	}
+
+	original, simplified := genWorkflowMap(workflows), genWorkflowMap(compiled)
+	for _, part := range parts {
+		want := isAccepted(part, original)
+		if got := isAccepted(part, simplified); got != want {
+			t.Errorf("%v: compiled workflows give accepted == %t, want %t", part, got, want)
+		}
+		if got := root.Accepts(part); got != want {
+			t.Errorf("%v: decision tree gives accepted == %t, want %t", part, got, want)
+		}
+	}
+}
+
+func TestSimplifyWorkflowsSample(t *testing.T) {
+	got, err := SimplifyWorkflows("../inputs/day19_sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// lnx and gd always give the same verdict, so qs and rfg's first rule collapse too
+	want := "in{s<1351:px,s>2770:A,m<1801:hdj,R}\n" +
+		"px{a<2006:qkq,m>2090:A,s<537:R,x>2440:R,A}\n" +
+		"qkq{x<1416:A,x>2662:A,R}\n" +
+		"hdj{m>838:A,a>1716:R,A}\n"
+	if got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestCompileSimplifies(t *testing.T) {
+	tests := []struct {
+		name  string
+		flows []string
+		want  string
+	}{
+		{"equal subtrees merged", []string{"in{x<10:a,b}", "a{m<5:A,R}", "b{m<5:A,R}"}, "in{m<5:A,R}"},
+		{"equal verdicts merged", []string{"in{x<10:A,m>5:A,A}"}, "in{A}"},
+		{"unreachable rule removed", []string{"in{x<10:A,x<5:R,m>3:R,A}"}, "in{x<10:A,m>3:R,A}"},
+		{"rule that always fires", []string{"in{x>0:px,R}", "px{m<5:A,R}"}, "in{m<5:A,R}"},
+		{"rule implied by an earlier one", []string{"in{x<10:px,R}", "px{x<20:A,R}"}, "in{x<10:A,R}"},
+		{"unreachable workflow removed", []string{"in{x<10:A,R}", "px{m<5:A,R}"}, "in{x<10:A,R}"},
+		{"inlined twice", []string{"in{x<10:px,a<5:px,R}", "px{m<5:A,R}"}, "in{x<10:px,a<5:px2,R}\npx{m<5:A,R}\npx2{m<5:A,R}"},
+	}
+
+	for _, test := range tests {
+		if got := compileToString(t, mustParseWorkflows(t, test.flows...)); got != test.want {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+		}
+	}
+}
+
+func TestCompileBadWorkflows(t *testing.T) {
+	for _, flows := range [][]string{
+		{"in{x<10:qq,R}"},
+		{"px{x<10:A,R}"},
+		{"in{x<10:px,R}", "px{m<5:in,A}"},
+		{"in{px}", "px{qq}", "qq{a<3:px,A}"},
+	} {
+		if _, err := Compile(mustParseWorkflows(t, flows...)); err == nil {
+			t.Errorf("%v: expected an error", flows)
+		}
+	}
+
+	// A cycle no part can reach isn't an error, since it's dropped as unreachable
+	if _, err := Compile(mustParseWorkflows(t, "in{x<10:A,x<5:px,R}", "px{m<5:px,R}")); err != nil {
+		t.Error(err)
+	}
+}
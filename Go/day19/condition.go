@@ -0,0 +1,426 @@
+package day19
+
+import (
+	"aoc/utils"
+	"fmt"
+	"strconv"
+)
+
+// The name of a rating category on a part
+type Cat string
+
+// The categories used by the puzzle.  Workflows and parts may use any other names as well.
+const (
+	XCat Cat = "x"
+	MCat Cat = "m"
+	ACat Cat = "a"
+	SCat Cat = "s"
+)
+
+type Op int
+
+const (
+	OpGreater Op = iota
+	OpLess
+	OpGreaterEq
+	OpLessEq
+	OpEqual
+	OpNotEqual
+	OpAnd
+	OpOr
+)
+
+// The textual form of each operator
+var opStrings = map[Op]string{
+	OpGreater:   ">",
+	OpLess:      "<",
+	OpGreaterEq: ">=",
+	OpLessEq:    "<=",
+	OpEqual:     "==",
+	OpNotEqual:  "!=",
+	OpAnd:       "&&",
+	OpOr:        "||",
+}
+
+// A condition on a part.  This is either a comparison of a category against a value, or (if the
+// operation is OpAnd or OpOr) a boolean combination of two other conditions.
+type Condition struct {
+	// The category compared (comparisons only)
+	Category Cat
+	// The comparison or boolean operation to perform
+	Operation Op
+	// The value compared against (comparisons only)
+	Value int
+	// The operands (OpAnd and OpOr only)
+	Left  *Condition
+	Right *Condition
+}
+
+// Returns whether the condition is a boolean combination rather than a comparison
+func (cnd *Condition) isBoolean() bool {
+	return cnd.Operation == OpAnd || cnd.Operation == OpOr
+}
+
+func (cnd *Condition) appliesTo(part Part) bool {
+	switch cnd.Operation {
+	case OpAnd:
+		return cnd.Left.appliesTo(part) && cnd.Right.appliesTo(part)
+	case OpOr:
+		return cnd.Left.appliesTo(part) || cnd.Right.appliesTo(part)
+	}
+
+	val, ok := part[cnd.Category]
+	if !ok {
+		panic("Unsupported category")
+	}
+
+	switch cnd.Operation {
+	case OpGreater:
+		return val > cnd.Value
+	case OpLess:
+		return val < cnd.Value
+	case OpGreaterEq:
+		return val >= cnd.Value
+	case OpLessEq:
+		return val <= cnd.Value
+	case OpEqual:
+		return val == cnd.Value
+	case OpNotEqual:
+		return val != cnd.Value
+	default:
+		panic("Unsupported op")
+	}
+}
+
+// Appends each non-empty range in rngs to result, as a copy of ranges with the condition's
+// category replaced by that range.
+func (cnd *Condition) appendBoxes(result []ValRange, ranges ValRange, rngs ...utils.Range) []ValRange {
+	for _, r := range rngs {
+		if r.Start <= r.End {
+			result = append(result, ranges.with(cnd.Category, r))
+		}
+	}
+
+	return result
+}
+
+// Splits the given ranges into the boxes for which the condition is true, and the boxes for which
+// it is false.  All boxes returned are disjoint, and together cover exactly the given ranges; either
+// list may be empty.
+func (cnd *Condition) split(ranges ValRange) (trueBoxes []ValRange, falseBoxes []ValRange) {
+	switch cnd.Operation {
+	case OpAnd:
+		// True only where both sides are true; false where either side is
+		leftTrue, leftFalse := cnd.Left.split(ranges)
+		falseBoxes = leftFalse
+		for _, box := range leftTrue {
+			t, f := cnd.Right.split(box)
+			trueBoxes = append(trueBoxes, t...)
+			falseBoxes = append(falseBoxes, f...)
+		}
+		return trueBoxes, falseBoxes
+	case OpOr:
+		// True where either side is true; false only where both sides are
+		leftTrue, leftFalse := cnd.Left.split(ranges)
+		trueBoxes = leftTrue
+		for _, box := range leftFalse {
+			t, f := cnd.Right.split(box)
+			trueBoxes = append(trueBoxes, t...)
+			falseBoxes = append(falseBoxes, f...)
+		}
+		return trueBoxes, falseBoxes
+	}
+
+	cur, ok := ranges[cnd.Category]
+	if !ok {
+		panic("Unsupported category")
+	}
+
+	below := utils.Range{Start: cur.Start, End: min(cur.End, cnd.Value-1)}
+	equal := utils.Range{Start: max(cur.Start, cnd.Value), End: min(cur.End, cnd.Value)}
+	above := utils.Range{Start: max(cur.Start, cnd.Value+1), End: cur.End}
+
+	switch cnd.Operation {
+	case OpLess:
+		return cnd.appendBoxes(nil, ranges, below), cnd.appendBoxes(nil, ranges, equal, above)
+	case OpLessEq:
+		return cnd.appendBoxes(nil, ranges, below, equal), cnd.appendBoxes(nil, ranges, above)
+	case OpGreater:
+		return cnd.appendBoxes(nil, ranges, above), cnd.appendBoxes(nil, ranges, below, equal)
+	case OpGreaterEq:
+		return cnd.appendBoxes(nil, ranges, equal, above), cnd.appendBoxes(nil, ranges, below)
+	case OpEqual:
+		return cnd.appendBoxes(nil, ranges, equal), cnd.appendBoxes(nil, ranges, below, above)
+	case OpNotEqual:
+		return cnd.appendBoxes(nil, ranges, below, above), cnd.appendBoxes(nil, ranges, equal)
+	default:
+		panic("Unsupported operation")
+	}
+}
+
+// Calls the given function for each category the condition compares against
+func (cnd *Condition) forEachCategory(f func(Cat)) {
+	if cnd.isBoolean() {
+		cnd.Left.forEachCategory(f)
+		cnd.Right.forEachCategory(f)
+	} else {
+		f(cnd.Category)
+	}
+}
+
+// Formats the condition the way the puzzle input does; eg. "a<2006" or "a<2006&&(x>5||m==3)"
+func (cnd Condition) String() string {
+	switch cnd.Operation {
+	case OpAnd:
+		// && binds tighter than ||, so an || operand needs parentheses
+		left, right := cnd.Left.String(), cnd.Right.String()
+		if cnd.Left.Operation == OpOr {
+			left = "(" + left + ")"
+		}
+		if cnd.Right.Operation == OpOr {
+			right = "(" + right + ")"
+		}
+		return left + "&&" + right
+	case OpOr:
+		return cnd.Left.String() + "||" + cnd.Right.String()
+	}
+
+	op, ok := opStrings[cnd.Operation]
+	if !ok {
+		panic("Unsupported op")
+	}
+
+	return string(cnd.Category) + op + strconv.Itoa(cnd.Value)
+}
+
+// The kinds of token a condition is made up of
+type tokenKind int
+
+const (
+	tokIdent tokenKind = iota
+	tokNumber
+	tokOp
+	tokLParen
+	tokRParen
+	tokEOF
+)
+
+type token struct {
+	Kind tokenKind
+	Text string
+	// 0-based offset of the token within the condition
+	Pos int
+}
+
+// An error in the syntax of a workflow, along with the (1-based) column it occurred at.
+type SyntaxError struct {
+	Column int
+	Msg    string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+func isIdentByte(b byte, first bool) bool {
+	return b == '_' || (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z') || (!first && b >= '0' && b <= '9')
+}
+
+// Returns whether s is a valid identifier; that is, a category or workflow name
+func isIdent(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isIdentByte(s[i], i == 0) {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// Splits a condition into tokens.  Whitespace between tokens is ignored.
+func tokenize(condition string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(condition); {
+		start := i
+		b := condition[i]
+		switch {
+		case b == ' ' || b == '\t':
+			i++
+			continue
+		case isIdentByte(b, true):
+			for i < len(condition) && isIdentByte(condition[i], false) {
+				i++
+			}
+			tokens = append(tokens, token{tokIdent, condition[start:i], start})
+		case isDigit(b) || (b == '-' && i+1 < len(condition) && isDigit(condition[i+1])):
+			i++
+			for i < len(condition) && isDigit(condition[i]) {
+				i++
+			}
+			tokens = append(tokens, token{tokNumber, condition[start:i], start})
+		case b == '(':
+			i++
+			tokens = append(tokens, token{tokLParen, "(", start})
+		case b == ')':
+			i++
+			tokens = append(tokens, token{tokRParen, ")", start})
+		default:
+			// Operators; check two-character ones first so "<=" isn't read as "<"
+			found := false
+			for _, length := range []int{2, 1} {
+				if i+length > len(condition) {
+					continue
+				}
+				for _, op := range opStrings {
+					if condition[i:i+length] == op {
+						i += length
+						tokens = append(tokens, token{tokOp, op, start})
+						found = true
+						break
+					}
+				}
+				if found {
+					break
+				}
+			}
+			if !found {
+				return nil, &SyntaxError{start + 1, fmt.Sprintf("unexpected character %q", b)}
+			}
+		}
+	}
+
+	return append(tokens, token{tokEOF, "", len(condition)}), nil
+}
+
+// Recursive descent parser over a list of tokens.  The grammar is:
+//
+//	expr       := andExpr ("||" andExpr)*
+//	andExpr    := primary ("&&" primary)*
+//	primary    := "(" expr ")" | comparison
+//	comparison := IDENT ("<" | "<=" | ">" | ">=" | "==" | "!=") NUMBER
+type conditionParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *conditionParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *conditionParser) next() token {
+	t := p.tokens[p.pos]
+	if t.Kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// Creates an error pointing at the given token
+func (p *conditionParser) errorAt(t token, format string, args ...any) error {
+	found := fmt.Sprintf("%q", t.Text)
+	if t.Kind == tokEOF {
+		found = "end of condition"
+	}
+	return &SyntaxError{t.Pos + 1, fmt.Sprintf(format, args...) + ", found " + found}
+}
+
+func (p *conditionParser) parseExpr() (*Condition, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().Kind == tokOp && p.peek().Text == "||" {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &Condition{Operation: OpOr, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *conditionParser) parseAnd() (*Condition, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.peek().Kind == tokOp && p.peek().Text == "&&" {
+		p.next()
+		right, err := p.parsePrimary()
+		if err != nil {
+			return nil, err
+		}
+		left = &Condition{Operation: OpAnd, Left: left, Right: right}
+	}
+
+	return left, nil
+}
+
+func (p *conditionParser) parsePrimary() (*Condition, error) {
+	t := p.next()
+	switch t.Kind {
+	case tokLParen:
+		cnd, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.Kind != tokRParen {
+			return nil, p.errorAt(closing, "expected \")\" to close \"(\"")
+		}
+		return cnd, nil
+	case tokIdent:
+		opTok := p.next()
+		var op Op = -1
+		if opTok.Kind == tokOp {
+			for k, v := range opStrings {
+				if v == opTok.Text && k != OpAnd && k != OpOr {
+					op = k
+				}
+			}
+		}
+		if op == -1 {
+			return nil, p.errorAt(opTok, "expected comparison operator after category %q", t.Text)
+		}
+
+		valTok := p.next()
+		if valTok.Kind != tokNumber {
+			return nil, p.errorAt(valTok, "expected integer after %q", opTok.Text)
+		}
+		val, err := strconv.Atoi(valTok.Text)
+		if err != nil {
+			return nil, &SyntaxError{valTok.Pos + 1, err.Error()}
+		}
+
+		return &Condition{Category: Cat(t.Text), Operation: op, Value: val}, nil
+	default:
+		return nil, p.errorAt(t, "expected category name or \"(\"")
+	}
+}
+
+// Parses a condition such as "a<2006" or "x>=10&&(m==3||s!=4)".
+func parseCondition(condition string) (*Condition, error) {
+	tokens, err := tokenize(condition)
+	if err != nil {
+		return nil, err
+	}
+
+	p := conditionParser{tokens: tokens}
+	cnd, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.peek(); t.Kind != tokEOF {
+		return nil, p.errorAt(t, "expected \"&&\", \"||\" or end of condition")
+	}
+
+	return cnd, nil
+}
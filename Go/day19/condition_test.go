@@ -0,0 +1,185 @@
+package day19
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// Shorthands for building expected conditions
+func cmp(c Cat, op Op, v int) *Condition {
+	return &Condition{Category: c, Operation: op, Value: v}
+}
+
+func and(l, r *Condition) *Condition {
+	return &Condition{Operation: OpAnd, Left: l, Right: r}
+}
+
+func or(l, r *Condition) *Condition {
+	return &Condition{Operation: OpOr, Left: l, Right: r}
+}
+
+func TestTokenize(t *testing.T) {
+	tokens, err := tokenize("x>=-10 && (m!=3)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []token{
+		{tokIdent, "x", 0},
+		{tokOp, ">=", 1},
+		{tokNumber, "-10", 3},
+		{tokOp, "&&", 7},
+		{tokLParen, "(", 10},
+		{tokIdent, "m", 11},
+		{tokOp, "!=", 12},
+		{tokNumber, "3", 14},
+		{tokRParen, ")", 15},
+		{tokEOF, "", 16},
+	}
+	if !reflect.DeepEqual(tokens, want) {
+		t.Errorf("got %v, want %v", tokens, want)
+	}
+}
+
+func TestParseCondition(t *testing.T) {
+	tests := []struct {
+		condition string
+		want      *Condition
+	}{
+		{"a<2006", cmp(ACat, OpLess, 2006)},
+		{"m>2090", cmp(MCat, OpGreater, 2090)},
+		{"x>=10", cmp(XCat, OpGreaterEq, 10)},
+		{"s<=-3", cmp(SCat, OpLessEq, -3)},
+		{"x==1", cmp(XCat, OpEqual, 1)},
+		{"shiny!=7", cmp("shiny", OpNotEqual, 7)},
+		// && binds tighter than ||, and both group to the left
+		{"a<1||m<2&&s<3", or(cmp(ACat, OpLess, 1), and(cmp(MCat, OpLess, 2), cmp(SCat, OpLess, 3)))},
+		{"a<1&&m<2||s<3", or(and(cmp(ACat, OpLess, 1), cmp(MCat, OpLess, 2)), cmp(SCat, OpLess, 3))},
+		{"a<1&&m<2&&s<3", and(and(cmp(ACat, OpLess, 1), cmp(MCat, OpLess, 2)), cmp(SCat, OpLess, 3))},
+		{"(a<1||m<2)&&s<3", and(or(cmp(ACat, OpLess, 1), cmp(MCat, OpLess, 2)), cmp(SCat, OpLess, 3))},
+		{" ( ( x > 5 ) ) ", cmp(XCat, OpGreater, 5)},
+	}
+
+	for _, test := range tests {
+		got, err := parseCondition(test.condition)
+		if err != nil {
+			t.Errorf("%q: %v", test.condition, err)
+		} else if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: got %v, want %v", test.condition, got, test.want)
+		}
+	}
+}
+
+func TestConditionString(t *testing.T) {
+	tests := []struct {
+		condition string
+		want      string
+	}{
+		{"a<2006", "a<2006"},
+		{"x >= -10", "x>=-10"},
+		{"a<1||m<2&&s<3", "a<1||m<2&&s<3"},
+		{"(a<1&&m<2)||s<3", "a<1&&m<2||s<3"},
+		{"(a<1||m<2)&&(s<3||x!=4)", "(a<1||m<2)&&(s<3||x!=4)"},
+		{"a==1&&(m<=2||s>3)", "a==1&&(m<=2||s>3)"},
+	}
+
+	for _, test := range tests {
+		cnd, err := parseCondition(test.condition)
+		if err != nil {
+			t.Fatalf("%q: %v", test.condition, err)
+		}
+
+		got := cnd.String()
+		if got != test.want {
+			t.Errorf("%q: got %q, want %q", test.condition, got, test.want)
+		}
+
+		// Parsing the formatted condition must give back the same condition
+		again, err := parseCondition(got)
+		if err != nil {
+			t.Errorf("%q: reparsing %q: %v", test.condition, got, err)
+		} else if !reflect.DeepEqual(again, cnd) {
+			t.Errorf("%q: reparsing %q gave %v, want %v", test.condition, got, again, cnd)
+		}
+	}
+}
+
+// Checks that err is a SyntaxError at the given column
+func checkSyntaxError(t *testing.T, input string, err error, column int) {
+	t.Helper()
+
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("%q: expected a SyntaxError, got %v", input, err)
+	} else if syntaxErr.Column != column {
+		t.Errorf("%q: got error at column %d, want %d (%v)", input, syntaxErr.Column, column, err)
+	}
+}
+
+func TestParseConditionErrors(t *testing.T) {
+	tests := []struct {
+		condition string
+		column    int
+	}{
+		{"", 1},
+		{"a=5", 2},
+		{"a$5", 2},
+		{"a 5", 3},
+		{"a-5", 2},
+		{"a<", 3},
+		{"a<b", 3},
+		{"5<a", 1},
+		{"a<5&&", 6},
+		{"a<5||&&m<2", 6},
+		{"(a<5", 5},
+		{"a<5)", 4},
+		{"a<5 m<2", 5},
+		{"a<99999999999999999999", 3},
+	}
+
+	for _, test := range tests {
+		_, err := parseCondition(test.condition)
+		checkSyntaxError(t, test.condition, err, test.column)
+	}
+}
+
+func TestParseWorkflow(t *testing.T) {
+	got, err := parseWorkflow("px{a<2006:qkq,m>2090&&x!=1:A,rfg}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Workflow{
+		ID: "px",
+		Rules: []Rule{
+			{Cnd: *cmp(ACat, OpLess, 2006), Dst: "qkq"},
+			{Cnd: *and(cmp(MCat, OpGreater, 2090), cmp(XCat, OpNotEqual, 1)), Dst: "A"},
+		},
+		DefaultRule: "rfg",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestParseWorkflowErrors(t *testing.T) {
+	tests := []struct {
+		workflow string
+		column   int
+	}{
+		{"px{a<2006:qkq,R", 16},
+		{"px{a<:A,R}", 6},
+		{"px{a<5,R}", 7},
+		{"px{a<5:,R}", 8},
+		{"px{a<5:A,}", 10},
+		// The last item must be a bare destination, not a rule
+		{"px{a<2006:qkq,m>2090:A}", 15},
+		{"px{a<2006:qkq,r-fg}", 15},
+	}
+
+	for _, test := range tests {
+		_, err := parseWorkflow(test.workflow)
+		checkSyntaxError(t, test.workflow, err, test.column)
+	}
+}
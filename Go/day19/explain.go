@@ -0,0 +1,120 @@
+package day19
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A single workflow visited while processing a part
+type TraceStep struct {
+	// The workflow visited
+	Workflow string
+	// The index of the rule which fired, or -1 if none did and the default rule was used
+	RuleIdx int
+	// Where the part was sent next
+	Dst string
+}
+
+// The full path of a part through the workflows
+type Trace struct {
+	Steps    []TraceStep
+	Accepted bool
+}
+
+// Formats the trace as a chain of workflows along with the rule that fired in each; eg.
+// "in[s<1351] -> px[a<2006] -> qkq[x<1416] -> A"
+func (t Trace) format(workflows map[string]Workflow) string {
+	var s strings.Builder
+	for _, step := range t.Steps {
+		s.WriteString(step.Workflow)
+		if step.RuleIdx == -1 {
+			s.WriteString("[default]")
+		} else {
+			s.WriteString("[" + workflows[step.Workflow].Rules[step.RuleIdx].Cnd.String() + "]")
+		}
+		s.WriteString(" -> ")
+	}
+
+	if t.Accepted {
+		s.WriteString("A")
+	} else {
+		s.WriteString("R")
+	}
+
+	return s.String()
+}
+
+// Runs the part through the workflows starting at "in", recording each workflow visited and the
+// rule that fired in it.
+func tracePart(part Part, workflows map[string]Workflow) (Trace, error) {
+	var trace Trace
+	visited := map[string]bool{}
+
+	curFlow := "in"
+	for curFlow != "A" && curFlow != "R" {
+		flow, ok := workflows[curFlow]
+		if !ok {
+			return trace, fmt.Errorf("workflow %q does not exist", curFlow)
+		}
+		if visited[curFlow] {
+			return trace, fmt.Errorf("workflow %q is part of a cycle", curFlow)
+		}
+		visited[curFlow] = true
+
+		step := TraceStep{Workflow: curFlow, RuleIdx: -1, Dst: flow.DefaultRule}
+		for i, rule := range flow.Rules {
+			// The part may have come from outside parseInput, so hasn't been checked against the
+			// categories the workflows use
+			var missing []Cat
+			rule.Cnd.forEachCategory(func(c Cat) {
+				if _, ok := part[c]; !ok {
+					missing = append(missing, c)
+				}
+			})
+			if len(missing) > 0 {
+				return trace, fmt.Errorf("workflow %q checks category %q, which the part has no rating for", curFlow, missing[0])
+			}
+
+			if rule.Cnd.appliesTo(part) {
+				step.RuleIdx = i
+				step.Dst = rule.Dst
+				break
+			}
+		}
+
+		trace.Steps = append(trace.Steps, step)
+		curFlow = step.Dst
+	}
+
+	trace.Accepted = curFlow == "A"
+	return trace, nil
+}
+
+// Reads the workflows and parts from the given input.
+func ReadInput(path string) ([]Workflow, []Part, error) {
+	return parseInput(path)
+}
+
+// Gets the full path the part takes through the workflows, and whether it ends up accepted.
+func ExplainPart(workflows []Workflow, part Part) (Trace, error) {
+	return tracePart(part, genWorkflowMap(workflows))
+}
+
+// Gets a human-readable explanation of why the part is accepted or rejected.
+func ExplainPartString(workflows []Workflow, part Part) (string, error) {
+	wfMap := genWorkflowMap(workflows)
+	trace, err := tracePart(part, wfMap)
+	if err != nil {
+		return "", err
+	}
+
+	return trace.format(wfMap), nil
+}
+
+// Gets the disjoint boxes of part values which lead from "in" to the given target, which may be
+// a workflow ID, "A" or "R".  Each box covers the categories the workflows reference (plus the
+// standard "xmas" categories), each ranging from 1 to 4000.  Returns an error if a workflow on the
+// way is missing or part of a cycle.
+func RangesLeadingTo(workflows []Workflow, target string) ([]ValRange, error) {
+	return collectRanges(getFullRange(workflows), genWorkflowMap(workflows), "in", target, map[string]bool{})
+}
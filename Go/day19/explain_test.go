@@ -0,0 +1,72 @@
+package day19
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExplainPart(t *testing.T) {
+	workflows, _, err := ReadInput("../inputs/day19_sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	part := Part{XCat: 787, MCat: 2655, ACat: 1222, SCat: 2876}
+	trace, err := ExplainPart(workflows, part)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Trace{
+		Steps: []TraceStep{
+			{Workflow: "in", RuleIdx: -1, Dst: "qqz"},
+			{Workflow: "qqz", RuleIdx: 0, Dst: "qs"},
+			{Workflow: "qs", RuleIdx: -1, Dst: "lnx"},
+			{Workflow: "lnx", RuleIdx: 0, Dst: "A"},
+		},
+		Accepted: true,
+	}
+	if !reflect.DeepEqual(trace, want) {
+		t.Errorf("got %+v, want %+v", trace, want)
+	}
+}
+
+func TestExplainPartString(t *testing.T) {
+	workflows, _, err := ReadInput("../inputs/day19_sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		part Part
+		want string
+	}{
+		{Part{XCat: 787, MCat: 2655, ACat: 1222, SCat: 2876}, "in[default] -> qqz[s>2770] -> qs[default] -> lnx[m>1548] -> A"},
+		{Part{XCat: 1679, MCat: 44, ACat: 2067, SCat: 496}, "in[s<1351] -> px[default] -> rfg[s<537] -> gd[default] -> R"},
+	}
+
+	for _, test := range tests {
+		got, err := ExplainPartString(workflows, test.part)
+		if err != nil {
+			t.Errorf("%v: %v", test.part, err)
+		} else if got != test.want {
+			t.Errorf("%v: got %q, want %q", test.part, got, test.want)
+		}
+	}
+}
+
+func TestExplainPartMissingCategory(t *testing.T) {
+	workflows, _, err := ReadInput("../inputs/day19_sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// "in" only checks s, but qqz goes on to check m
+	part := Part{XCat: 787, ACat: 1222, SCat: 1500}
+	if _, err := ExplainPart(workflows, part); err == nil {
+		t.Error("expected an error for a part with no m rating")
+	}
+	if _, err := ExplainPartString(workflows, Part{}); err == nil {
+		t.Error("expected an error for a part with no ratings")
+	}
+}
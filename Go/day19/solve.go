@@ -4,58 +4,12 @@ import (
 	"aoc/utils"
 	"bufio"
 	"errors"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
 )
 
-type Cat int
-
-const (
-	XCat Cat = iota
-	MCat
-	ACat
-	SCat
-)
-
-type Op int
-
-const (
-	OpGreater Op = iota
-	OpLess
-)
-
-type Condition struct {
-	Category  Cat
-	Operation Op
-	Value     int
-}
-
-func (cnd *Condition) appliesTo(part Part) bool {
-	var val int
-	switch cnd.Category {
-	case XCat:
-		val = part.X
-	case MCat:
-		val = part.M
-	case ACat:
-		val = part.A
-	case SCat:
-		val = part.S
-	default:
-		panic("Unsupported op")
-	}
-
-	switch cnd.Operation {
-	case OpGreater:
-		return val > cnd.Value
-	case OpLess:
-		return val < cnd.Value
-	default:
-		panic("Unsupported op")
-	}
-}
-
 type Rule struct {
 	Cnd Condition
 	Dst string
@@ -67,104 +21,132 @@ type Workflow struct {
 	DefaultRule string
 }
 
-type Part struct {
-	X int
-	M int
-	A int
-	S int
-}
+// Maps each category to the part's rating in that category
+type Part map[Cat]int
 
+// Parses a rule in the form "cond:dst".  The column of any syntax error is relative to the start
+// of the rule.
 func parseRule(rule string) (Rule, error) {
-	scanner := utils.NewStringDelimiterScanner(rule, ":")
-
-	condition, err := utils.ReadStringFromScanner(scanner)
-	if err != nil {
-		return Rule{}, err
-	}
-
-	var cat Cat
-	switch condition[0] {
-	case 'x':
-		cat = XCat
-	case 'm':
-		cat = MCat
-	case 'a':
-		cat = ACat
-	case 's':
-		cat = SCat
-	default:
-		return Rule{}, errors.New("invalid category")
-	}
-
-	var op Op
-	switch condition[1] {
-	case '<':
-		op = OpLess
-	case '>':
-		op = OpGreater
-	default:
-		return Rule{}, errors.New("invalid op")
+	idx := strings.LastIndex(rule, ":")
+	if idx == -1 {
+		return Rule{}, &SyntaxError{len(rule) + 1, "expected \":\" followed by a destination"}
 	}
 
-	val, err := strconv.Atoi(condition[2:])
+	cnd, err := parseCondition(rule[:idx])
 	if err != nil {
 		return Rule{}, err
 	}
 
-	dst, err := utils.ReadStringFromScanner(scanner)
-	if err != nil {
-		return Rule{}, err
+	dst := rule[idx+1:]
+	if dst == "" {
+		return Rule{}, &SyntaxError{idx + 2, "expected destination after \":\""}
 	}
 
-	return Rule{Cnd: Condition{Category: cat, Operation: op, Value: val}, Dst: dst}, nil
+	return Rule{Cnd: *cnd, Dst: dst}, nil
 }
 
+// Parses a workflow in the form "id{rule,rule,...,default}".  The column of any syntax error is
+// relative to the start of the workflow.
 func parseWorkflow(workflow string) (Workflow, error) {
 	idx := strings.IndexRune(workflow, '{')
 	if idx == -1 {
 		return Workflow{}, errors.New("workflow ID not found")
 	}
+	if !strings.HasSuffix(workflow, "}") {
+		return Workflow{}, &SyntaxError{len(workflow) + 1, "expected \"}\" at end of workflow"}
+	}
 
 	id := workflow[:idx]
 
-	data := workflow[idx+1 : len(workflow)-1]
-	idx = strings.LastIndex(data, ",")
+	// Rules can't contain commas, so we can split on them while keeping track of where each rule
+	// starts, for error reporting
+	offset := idx + 1
+	items := strings.Split(workflow[idx+1:len(workflow)-1], ",")
 
-	// Parse out everything
 	var rules []Rule
-	var defaultRule string
-	if idx == -1 {
-		defaultRule = data
-	} else {
-		defaultRule = data[idx+1:]
-		r, err := utils.ReadItems(utils.NewStringDelimiterScanner(data[:idx], ","), parseRule, false)
+	for _, item := range items[:len(items)-1] {
+		r, err := parseRule(item)
 		if err != nil {
+			var syntaxErr *SyntaxError
+			if errors.As(err, &syntaxErr) {
+				return Workflow{}, &SyntaxError{syntaxErr.Column + offset, syntaxErr.Msg}
+			}
 			return Workflow{}, err
 		}
 
-		rules = r
+		rules = append(rules, r)
+		offset += len(item) + 1
 	}
 
-	return Workflow{ID: id, Rules: rules, DefaultRule: defaultRule}, nil
-}
+	// The default is a bare destination; anything else is most likely a rule missing its default
+	defaultRule := items[len(items)-1]
+	if defaultRule == "" {
+		return Workflow{}, &SyntaxError{offset + 1, "expected default destination"}
+	}
+	if !isIdent(defaultRule) {
+		return Workflow{}, &SyntaxError{offset + 1, fmt.Sprintf("default destination %q is not a workflow name", defaultRule)}
+	}
 
-func parsePartValue(partValueData string) (int, error) {
-	return strconv.Atoi(partValueData[2:])
+	return Workflow{ID: id, Rules: rules, DefaultRule: defaultRule}, nil
 }
 
+// Parses a part in the form "{x=787,m=2655,...}".  Any category names are allowed.
 func parsePart(part string) (Part, error) {
-	scanner := utils.NewStringDelimiterScanner(part[1:len(part)-1], ",")
+	if len(part) < 2 || part[0] != '{' || part[len(part)-1] != '}' {
+		return nil, errors.New("part must be enclosed in braces")
+	}
+
+	result := Part{}
+	for _, item := range strings.Split(part[1:len(part)-1], ",") {
+		name, value, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("expected \"=\" in part value %q", item)
+		}
 
-	partVals, err := utils.ReadItems(scanner, parsePartValue, false)
+		v, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := result[Cat(name)]; ok {
+			return nil, fmt.Errorf("category %q given more than once", name)
+		}
+		result[Cat(name)] = v
+	}
+
+	return result, nil
+}
+
+// Reads each line of data with the given parser, adding the line number to any error
+func parseLines[T any](data string, firstLine int, parser func(string) (T, error)) ([]T, error) {
+	lines, err := utils.ReadItemsFromString(data, bufio.ScanLines, func(s string) (string, error) { return s, nil }, false)
 	if err != nil {
-		return Part{}, nil
+		return nil, err
+	}
+
+	var result []T
+	for i, line := range lines {
+		item, err := parser(line)
+		if err != nil {
+			return result, fmt.Errorf("line %d: %w", firstLine+i, err)
+		}
+
+		result = append(result, item)
 	}
 
-	if len(partVals) != 4 {
-		return Part{}, errors.New("incorrect part value specification")
+	return result, nil
+}
+
+// Gets every category referenced by the given workflows
+func getCategories(workflows []Workflow) map[Cat]bool {
+	categories := map[Cat]bool{}
+	for _, wf := range workflows {
+		for _, r := range wf.Rules {
+			r.Cnd.forEachCategory(func(c Cat) { categories[c] = true })
+		}
 	}
 
-	return Part{X: partVals[0], M: partVals[1], A: partVals[2], S: partVals[3]}, nil
+	return categories
 }
 
 func parseInput(path string) ([]Workflow, []Part, error) {
@@ -181,7 +163,7 @@ func parseInput(path string) ([]Workflow, []Part, error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	workflows, err := utils.ReadItemsFromString(wfData, bufio.ScanLines, parseWorkflow, false)
+	workflows, err := parseLines(wfData, 1, parseWorkflow)
 	if err != nil {
 		return workflows, nil, err
 	}
@@ -190,11 +172,22 @@ func parseInput(path string) ([]Workflow, []Part, error) {
 	if err != nil {
 		return workflows, nil, err
 	}
-	parts, err := utils.ReadItemsFromString(partData, bufio.ScanLines, parsePart, false)
+	partsStart := len(workflows) + 2
+	parts, err := parseLines(partData, partsStart, parsePart)
 	if err != nil {
 		return workflows, parts, err
 	}
 
+	// Every part must have a rating for every category the workflows check
+	categories := getCategories(workflows)
+	for i, part := range parts {
+		for c := range categories {
+			if _, ok := part[c]; !ok {
+				return workflows, parts, fmt.Errorf("line %d: part has no rating for category %q", partsStart+i, c)
+			}
+		}
+	}
+
 	return workflows, parts, nil
 }
 
@@ -207,98 +200,117 @@ func genWorkflowMap(workflows []Workflow) map[string]Workflow {
 	return wfMap
 }
 
-type ValRange struct {
-	X utils.Range
-	M utils.Range
-	A utils.Range
-	S utils.Range
+// Maps each category to a range of values for that category
+type ValRange map[Cat]utils.Range
+
+// Gets a copy of the ranges with the range for the given category replaced
+func (ranges ValRange) with(cat Cat, rng utils.Range) ValRange {
+	result := make(ValRange, len(ranges))
+	for k, v := range ranges {
+		result[k] = v
+	}
+	result[cat] = rng
+
+	return result
 }
 
-func countAccepted(ranges ValRange, workflows map[string]Workflow, curFlow string) int {
-	if curFlow == "A" {
-		prod := 1
-		prod *= ranges.X.End - ranges.X.Start + 1
-		prod *= ranges.M.End - ranges.M.Start + 1
-		prod *= ranges.A.End - ranges.A.Start + 1
-		prod *= ranges.S.End - ranges.S.Start + 1
+// Gets the number of distinct parts which fall within the ranges
+func (ranges ValRange) count() int {
+	prod := 1
+	for _, r := range ranges {
+		prod *= r.Length()
+	}
 
-		return prod
-	} else if curFlow == "R" {
-		return 0
+	return prod
+}
+
+// Gets the ranges covering every possible part; each category referenced by the workflows, as
+// well as the standard "xmas" categories, may be anywhere from 1 to 4000.
+func getFullRange(workflows []Workflow) ValRange {
+	ranges := ValRange{}
+	for _, c := range []Cat{XCat, MCat, ACat, SCat} {
+		ranges[c] = utils.Range{Start: 1, End: 4000}
+	}
+	for c := range getCategories(workflows) {
+		ranges[c] = utils.Range{Start: 1, End: 4000}
 	}
 
-	var count int
+	return ranges
+}
 
-	flow := workflows[curFlow]
-	allValuesCovered := false
-	for _, r := range flow.Rules {
-		var cur utils.Range
-		switch r.Cnd.Category {
-		case XCat:
-			cur = ranges.X
-		case MCat:
-			cur = ranges.M
-		case ACat:
-			cur = ranges.A
-		case SCat:
-			cur = ranges.S
-		default:
-			panic("Unsupported category")
-		}
+// Finds the boxes of part values, within the given ranges, which lead from curFlow to the target
+// workflow (or to "A"/"R").  Once a box reaches the target it is recorded and not followed any
+// further.  visiting holds the workflows on the current path, so cycles can be detected.
+func collectRanges(ranges ValRange, workflows map[string]Workflow, curFlow string, target string, visiting map[string]bool) ([]ValRange, error) {
+	if curFlow == target {
+		return []ValRange{ranges}, nil
+	} else if curFlow == "A" || curFlow == "R" {
+		return nil, nil
+	}
 
-		var trueRange, falseRange utils.Range
-		switch r.Cnd.Operation {
-		case OpLess:
-			trueRange = utils.Range{Start: cur.Start, End: r.Cnd.Value - 1}
-			falseRange = utils.Range{Start: r.Cnd.Value, End: cur.End}
-		case OpGreater:
-			trueRange = utils.Range{Start: r.Cnd.Value + 1, End: cur.End}
-			falseRange = utils.Range{Start: cur.Start, End: r.Cnd.Value}
-		default:
-			panic("Unsupported operation")
-		}
+	flow, ok := workflows[curFlow]
+	if !ok {
+		return nil, fmt.Errorf("workflow %q does not exist", curFlow)
+	}
+	if visiting[curFlow] {
+		return nil, fmt.Errorf("workflow %q is part of a cycle", curFlow)
+	}
+	visiting[curFlow] = true
+	defer delete(visiting, curFlow)
 
-		if trueRange.Start <= trueRange.End {
-			rng := ranges
-			switch r.Cnd.Category {
-			case XCat:
-				rng.X = trueRange
-			case MCat:
-				rng.M = trueRange
-			case ACat:
-				rng.A = trueRange
-			case SCat:
-				rng.S = trueRange
+	var result []ValRange
+
+	// Each rule splits the boxes that reach it into the ones it sends on and the ones which fall
+	// through to the next rule
+	remaining := []ValRange{ranges}
+	for _, r := range flow.Rules {
+		var next []ValRange
+		for _, box := range remaining {
+			trueBoxes, falseBoxes := r.Cnd.split(box)
+			for _, t := range trueBoxes {
+				boxes, err := collectRanges(t, workflows, r.Dst, target, visiting)
+				if err != nil {
+					return nil, err
+				}
+				result = append(result, boxes...)
 			}
-			count += countAccepted(rng, workflows, r.Dst)
+			next = append(next, falseBoxes...)
 		}
 
-		if falseRange.Start <= falseRange.End {
-			rng := ranges
-			switch r.Cnd.Category {
-			case XCat:
-				rng.X = falseRange
-			case MCat:
-				rng.M = falseRange
-			case ACat:
-				rng.A = falseRange
-			case SCat:
-				rng.S = falseRange
-			}
+		remaining = next
+	}
 
-			ranges = rng
-		} else {
-			allValuesCovered = true
-			break
+	for _, box := range remaining {
+		boxes, err := collectRanges(box, workflows, flow.DefaultRule, target, visiting)
+		if err != nil {
+			return nil, err
 		}
+		result = append(result, boxes...)
+	}
+
+	return result, nil
+}
 
+func countAccepted(ranges ValRange, workflows map[string]Workflow, curFlow string) (int, error) {
+	boxes, err := collectRanges(ranges, workflows, curFlow, "A", map[string]bool{})
+	if err != nil {
+		return 0, err
 	}
 
-	if !allValuesCovered {
-		count += countAccepted(ranges, workflows, flow.DefaultRule)
+	count := 0
+	for _, r := range boxes {
+		count += r.count()
 	}
 
-	return count
+	return count, nil
+}
+
+// Runs the part through the workflows, starting at "in", and returns whether it is accepted.
+func isAccepted(part Part, workflows map[string]Workflow) bool {
+	trace, err := tracePart(part, workflows)
+	utils.CheckError(err)
+
+	return trace.Accepted
 }
 
 func PartA(path string) int {
@@ -309,34 +321,11 @@ func PartA(path string) int {
 
 	sum := 0
 	for _, part := range parts {
-		curFlow := "in"
-
-		accepted := false
-		for {
-			ruleFound := false
-			for _, rule := range workflows[curFlow].Rules {
-				if rule.Cnd.appliesTo(part) {
-					ruleFound = true
-					curFlow = rule.Dst
-					break
-				}
-			}
-
-			if !ruleFound {
-				curFlow = workflows[curFlow].DefaultRule
-			}
-
-			if curFlow == "R" {
-				break
-			} else if curFlow == "A" {
-				accepted = true
-				break
+		if isAccepted(part, workflows) {
+			for _, v := range part {
+				sum += v
 			}
 		}
-
-		if accepted {
-			sum += part.X + part.M + part.A + part.S
-		}
 	}
 
 	return sum
@@ -348,5 +337,8 @@ func PartB(path string) int {
 
 	workflows := genWorkflowMap(workflowList)
 
-	return countAccepted(ValRange{X: utils.Range{Start: 1, End: 4000}, M: utils.Range{Start: 1, End: 4000}, A: utils.Range{Start: 1, End: 4000}, S: utils.Range{Start: 1, End: 4000}}, workflows, "in")
+	count, err := countAccepted(getFullRange(workflowList), workflows, "in")
+	utils.CheckError(err)
+
+	return count
 }
@@ -0,0 +1,33 @@
+package day19
+
+import (
+	"aoc/utils/testutil"
+	"testing"
+)
+
+func BenchmarkDay(b *testing.B) {
+	testutil.BenchmarkDay(b, 19, func(path string) error { _, _, err := parseInput(path); return err }, PartA, PartB)
+}
+
+func TestRangesLeadingToBadWorkflows(t *testing.T) {
+	for _, flows := range [][]string{
+		{"in{x<10:A,px}"},
+		{"in{x<10:A,px}", "px{m>5:in,R}"},
+		{"in{px}", "px{qq}", "qq{a<3:px,A}"},
+	} {
+		var workflows []Workflow
+		for _, f := range flows {
+			w, err := parseWorkflow(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			workflows = append(workflows, w)
+		}
+
+		for _, target := range []string{"A", "R"} {
+			if _, err := RangesLeadingTo(workflows, target); err == nil {
+				t.Errorf("%v: expected an error finding ranges leading to %s", flows, target)
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+//go:build reference
+
+package differential
+
+import (
+	"aoc/day05"
+	"aoc/day06"
+	"aoc/day11"
+	"aoc/day12"
+	"aoc/day14"
+	"aoc/day18"
+	"aoc/gen"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The optimized and reference versions of one part of a day
+type Pair struct {
+	Fast      func(path string) int
+	Reference func(path string) int
+}
+
+// Both parts of each day with a reference version
+var Pairs = map[int][2]Pair{
+	5:  {{day05.PartA, day05.ReferencePartA}, {day05.PartB, day05.ReferencePartB}},
+	6:  {{day06.PartA, day06.ReferencePartA}, {day06.PartB, day06.ReferencePartB}},
+	11: {{day11.PartA, day11.ReferencePartA}, {day11.PartB, day11.ReferencePartB}},
+	12: {{day12.PartA, day12.ReferencePartA}, {day12.PartB, day12.ReferencePartB}},
+	14: {{day14.PartA, day14.ReferencePartA}, {day14.PartB, day14.ReferencePartB}},
+	18: {{day18.PartA, day18.ReferencePartA}, {day18.PartB, day18.ReferencePartB}},
+}
+
+// What one version of a solver gave for an input: its answer, or the value it panicked with.
+type Result struct {
+	Answer   int
+	Panicked bool
+	Panic    string
+}
+
+func (r Result) String() string {
+	if r.Panicked {
+		return "panic: " + r.Panic
+	}
+	return fmt.Sprint(r.Answer)
+}
+
+// The results of both versions for an input
+type Outcome struct {
+	Fast, Reference Result
+}
+
+// Returns whether the versions disagree; either they give different answers, or only one panics.
+// Inputs both versions panic on aren't valid, so don't count.
+func (o Outcome) Disagrees() bool {
+	if o.Fast.Panicked || o.Reference.Panicked {
+		return o.Fast.Panicked != o.Reference.Panicked
+	}
+	return o.Fast.Answer != o.Reference.Answer
+}
+
+// Returns whether o shows the same disagreement as other: different answers again, or the same
+// version panicking with the same value.  Shrinking keeps to the same disagreement, so it can't
+// wander off to inputs which are simply invalid for one version.
+func (o Outcome) SameDisagreement(other Outcome) bool {
+	if !o.Disagrees() || !other.Disagrees() {
+		return false
+	}
+
+	return o.Fast.Panicked == other.Fast.Panicked && o.Fast.Panic == other.Fast.Panic &&
+		o.Reference.Panicked == other.Reference.Panicked && o.Reference.Panic == other.Reference.Panic
+}
+
+// Runs a solver on the input in the given file, catching any panic.
+func run(solve func(string) int, path string) (result Result) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = Result{Panicked: true, Panic: fmt.Sprint(r)}
+		}
+	}()
+
+	return Result{Answer: solve(path)}
+}
+
+// Runs both versions of a part on the given input, using dir for scratch files.
+func Compare(pair Pair, input, dir string) (Outcome, error) {
+	path := filepath.Join(dir, "input.txt")
+	if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+		return Outcome{}, err
+	}
+
+	return Outcome{run(pair.Fast, path), run(pair.Reference, path)}, nil
+}
+
+// Shrinks an input by deleting lines for as long as keep says the result is still interesting.
+// Tries deleting big chunks first, then smaller and smaller ones down to single lines.
+func Shrink(input string, keep func(string) bool) string {
+	trailing := strings.HasSuffix(input, "\n")
+	lines := strings.Split(strings.TrimSuffix(input, "\n"), "\n")
+	join := func(lines []string) string {
+		s := strings.Join(lines, "\n")
+		if trailing {
+			s += "\n"
+		}
+		return s
+	}
+
+	for chunk := len(lines) / 2; chunk >= 1; {
+		shrunk := false
+		for start := 0; start < len(lines) && len(lines) > 1; {
+			end := min(start+chunk, len(lines))
+			candidate := append(append([]string{}, lines[:start]...), lines[end:]...)
+			if len(candidate) > 0 && keep(join(candidate)) {
+				lines = candidate
+				shrunk = true
+			} else {
+				start += chunk
+			}
+		}
+
+		// Once single lines can't be deleted, we're done; otherwise go round again with smaller
+		// chunks (or single lines again, since deleting one can let another go)
+		if chunk == 1 && !shrunk {
+			break
+		}
+		chunk = max(chunk/2, 1)
+	}
+
+	return join(lines)
+}
+
+// A generated input which the two versions of a part disagree on
+type Failure struct {
+	Day  int
+	Part byte
+	// The seed and size the failing input was generated from, before deleting lines
+	Seed int64
+	Size int
+	// The smallest failing input found
+	Input   string
+	Outcome Outcome
+}
+
+func (f Failure) String() string {
+	return fmt.Sprintf("day %d part %c (seed %d, size %d): fast version gave %v, reference gave %v, for input:\n%s",
+		f.Day, f.Part, f.Seed, f.Size, f.Outcome.Fast, f.Outcome.Reference, f.Input)
+}
+
+// Compares the two versions of a part on inputs from the given seed, generated at the given size.
+// If they disagree, returns the smallest input found they still disagree on in the same way.
+func Check(day int, part byte, seed int64, size int, dir string) (*Failure, error) {
+	return checkPair(Pairs[day][part-'A'], day, part, seed, size, dir)
+}
+
+func checkPair(pair Pair, day int, part byte, seed int64, size int, dir string) (*Failure, error) {
+	outcomeFor := func(size int) (string, Outcome, error) {
+		input, err := gen.Generate(day, seed, size)
+		if err != nil {
+			return "", Outcome{}, err
+		}
+		outcome, err := Compare(pair, input, dir)
+		return input, outcome, err
+	}
+
+	input, outcome, err := outcomeFor(size)
+	if err != nil || !outcome.Disagrees() {
+		return nil, err
+	}
+
+	// Look for a smaller generated input first, since it's a whole valid input rather than a
+	// fragment of one
+	failure := &Failure{Day: day, Part: part, Seed: seed, Size: size, Input: input, Outcome: outcome}
+	for smaller := 1; smaller < size; smaller++ {
+		input, o, err := outcomeFor(smaller)
+		if err != nil {
+			return nil, err
+		}
+		if o.SameDisagreement(outcome) {
+			failure.Size, failure.Input, failure.Outcome = smaller, input, o
+			break
+		}
+	}
+
+	var shrinkErr error
+	failure.Input = Shrink(failure.Input, func(candidate string) bool {
+		o, err := Compare(pair, candidate, dir)
+		if err != nil {
+			shrinkErr = err
+			return false
+		}
+		if o.SameDisagreement(outcome) {
+			failure.Outcome = o
+			return true
+		}
+		return false
+	})
+
+	return failure, shrinkErr
+}
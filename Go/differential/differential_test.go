@@ -0,0 +1,87 @@
+//go:build reference
+
+package differential
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+var (
+	seeds = flag.Int("seeds", 20, "Number of seeds to try for each part.")
+	size  = flag.Int("size", 12, "Size of the inputs to generate.")
+)
+
+func TestFastMatchesReference(t *testing.T) {
+	var days []int
+	for day := range Pairs {
+		days = append(days, day)
+	}
+	slices.Sort(days)
+
+	for _, day := range days {
+		for _, part := range []byte{'A', 'B'} {
+			day, part := day, part
+			t.Run(fmt.Sprintf("day%02d/%c", day, part), func(t *testing.T) {
+				t.Parallel()
+
+				dir := t.TempDir()
+				for seed := int64(1); seed <= int64(*seeds); seed++ {
+					failure, err := Check(day, part, seed, *size, dir)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if failure != nil {
+						t.Fatal(failure)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestShrink(t *testing.T) {
+	input := "a\nb\nc\nd\ne\nf\ng\nh\n"
+
+	// Interesting as long as it still has both c and f
+	keep := func(s string) bool { return strings.Contains(s, "c") && strings.Contains(s, "f") }
+	if shrunk := Shrink(input, keep); shrunk != "c\nf\n" {
+		t.Errorf("got %q, expected %q", shrunk, "c\nf\n")
+	}
+}
+
+// A broken solver should be caught, with the failing input shrunk down to the row that breaks it
+func TestCheckShrinksFailures(t *testing.T) {
+	reference := Pairs[11][0].Reference
+
+	// Wrong whenever a row has more than one galaxy
+	broken := func(path string) int {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			panic(err)
+		}
+		for _, row := range strings.Split(string(data), "\n") {
+			if strings.Count(row, "#") > 1 {
+				return -1
+			}
+		}
+		return reference(path)
+	}
+
+	failure, err := checkPair(Pair{broken, reference}, 11, 'A', 1, 12, t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failure == nil {
+		t.Fatal("broken solver wasn't caught")
+	}
+
+	rows := strings.Split(strings.TrimSpace(failure.Input), "\n")
+	if len(rows) != 1 || strings.Count(rows[0], "#") < 2 {
+		t.Errorf("expected a single row with at least two galaxies; got:\n%s", failure.Input)
+	}
+}
@@ -0,0 +1,13 @@
+// Compares the optimized solvers against slow but obvious reference versions on random inputs from
+// the gen package.  The reference versions live in each day's reference.go, as ReferencePartA and
+// ReferencePartB.  They take the slow but obvious route wherever the real solution is clever, so
+// they're only practical on small inputs.  They're only built with the "reference" build tag, as is
+// everything else here:
+//
+//	go test -tags reference ./differential
+//	go test -tags reference ./differential -seeds 500 -size 30
+//
+// When the two versions disagree on an input, it's shrunk to a smaller input they still disagree
+// on, first by generating smaller inputs from the same seed and then by deleting lines, and the
+// smallest one found is reported.
+package differential
@@ -0,0 +1,100 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+)
+
+// Options for generating a day 5 almanac
+type Day05Options struct {
+	// Number of seed ranges; there are twice as many seed numbers
+	SeedRanges int
+	// Every seed and every map's sources and destinations lie within [0, Span)
+	Span int
+	// Number of pieces each map cuts [0, Span) into
+	Pieces int
+	// Chance each piece is moved by a map, rather than passing through unchanged
+	Moved float64
+	// Chance each seed range overlaps the one before it
+	SeedOverlap float64
+}
+
+// Gets options for an almanac whose maps have the given number of pieces, most of which move
+func DefaultDay05Options(size int) Day05Options {
+	return Day05Options{SeedRanges: 4, Span: 100 * size, Pieces: size, Moved: 0.7, SeedOverlap: 0.2}
+}
+
+// Generates one map of an almanac.  Each map cuts [0, span) into pieces and lays them out again in
+// a different order, so no two sources map to the same destination.  Pieces which end up where
+// they started are left out, since they pass through unchanged anyway.
+func almanacMap(rng *rand.Rand, opts Day05Options) []string {
+	// Pick distinct cut points
+	cuts := map[int]bool{0: true, opts.Span: true}
+	for len(cuts) < min(opts.Pieces, opts.Span)+1 {
+		cuts[between(rng, 1, opts.Span-1)] = true
+	}
+	var bounds []int
+	for c := range cuts {
+		bounds = append(bounds, c)
+	}
+	slices.Sort(bounds)
+
+	// Shuffle the moved pieces between themselves, keeping the rest in order
+	order := make([]int, len(bounds)-1)
+	var moved []int
+	for i := range order {
+		order[i] = i
+		if rng.Float64() < opts.Moved {
+			moved = append(moved, i)
+		}
+	}
+	perm := rng.Perm(len(moved))
+	for i, m := range moved {
+		order[m] = moved[perm[i]]
+	}
+
+	var lines []string
+	dst := 0
+	for _, piece := range order {
+		src, length := bounds[piece], bounds[piece+1]-bounds[piece]
+		if src != dst {
+			lines = append(lines, fmt.Sprintf("%d %d %d", dst, src, length))
+		}
+		dst += length
+	}
+
+	rng.Shuffle(len(lines), func(i, j int) { lines[i], lines[j] = lines[j], lines[i] })
+	return lines
+}
+
+// Generates an almanac of seed ranges and the seven standard maps.
+func Day05(rng *rand.Rand, opts Day05Options) string {
+	opts.Span = max(opts.Span, 2)
+	opts.Pieces = max(opts.Pieces, 1)
+
+	// Keep seed ranges short enough that several fit in the span
+	maxLength := max(opts.Span/max(opts.SeedRanges, 1)/2, 1)
+	var seeds []string
+	prevStart, prevLength := 0, 0
+	for i := 0; i < opts.SeedRanges; i++ {
+		length := between(rng, 1, maxLength)
+		start := rng.Intn(opts.Span - length + 1)
+		if i > 0 && rng.Float64() < opts.SeedOverlap {
+			start = min(prevStart+rng.Intn(prevLength), opts.Span-length)
+		}
+
+		seeds = append(seeds, fmt.Sprint(start), fmt.Sprint(length))
+		prevStart, prevLength = start, length
+	}
+
+	categories := []string{"seed", "soil", "fertilizer", "water", "light", "temperature", "humidity", "location"}
+	groups := []string{"seeds: " + strings.Join(seeds, " ")}
+	for i := 0; i+1 < len(categories); i++ {
+		lines := append([]string{fmt.Sprintf("%s-to-%s map:", categories[i], categories[i+1])}, almanacMap(rng, opts)...)
+		groups = append(groups, strings.Join(lines, "\n"))
+	}
+
+	return strings.Join(groups, "\n\n") + "\n"
+}
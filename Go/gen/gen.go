@@ -0,0 +1,110 @@
+// Generates random, valid puzzle inputs, for stress testing the solvers on more than the sample and
+// real inputs.
+//
+// Every implemented day has a generator taking a random source and a size, which is roughly the
+// number of lines (or the width and height of a grid) to produce.  Days whose inputs have more
+// interesting structure also have a DayNNOptions type with finer-grained knobs, and a default set
+// of options for a given size.  The same seed and options always give the same input.
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Generates an input of roughly the given size
+type Generator func(rng *rand.Rand, size int) string
+
+// Generator for each implemented day
+var Generators = map[int]Generator{
+	1:  Day01,
+	2:  Day02,
+	3:  Day03,
+	4:  Day04,
+	5:  func(rng *rand.Rand, size int) string { return Day05(rng, DefaultDay05Options(size)) },
+	6:  Day06,
+	7:  Day07,
+	8:  func(rng *rand.Rand, size int) string { return Day08(rng, DefaultDay08Options(size)) },
+	9:  Day09,
+	10: func(rng *rand.Rand, size int) string { return Day10(rng, DefaultDay10Options(size)) },
+	11: Day11,
+	12: func(rng *rand.Rand, size int) string { return Day12(rng, DefaultDay12Options(size)) },
+	13: func(rng *rand.Rand, size int) string { return Day13(rng, DefaultDay13Options(size)) },
+	14: Day14,
+	15: Day15,
+	16: Day16,
+	17: func(rng *rand.Rand, size int) string { return Day17(rng, DefaultDay17Options(size)) },
+	18: func(rng *rand.Rand, size int) string { return Day18(rng, DefaultDay18Options(size)) },
+	19: func(rng *rand.Rand, size int) string { return Day19(rng, DefaultDay19Options(size)) },
+}
+
+// Generates an input for the given day from the given seed.
+func Generate(day int, seed int64, size int) (string, error) {
+	g, ok := Generators[day]
+	if !ok {
+		return "", fmt.Errorf("no generator for day %d", day)
+	}
+	if size < 1 {
+		return "", fmt.Errorf("size must be at least 1; got %d", size)
+	}
+
+	return g(rand.New(rand.NewSource(seed)), size), nil
+}
+
+// Gets a random integer in [lo, hi]
+func between(rng *rand.Rand, lo, hi int) int {
+	return lo + rng.Intn(hi-lo+1)
+}
+
+// Gets a random element of the given slice
+func choose[T any](rng *rand.Rand, items []T) T {
+	return items[rng.Intn(len(items))]
+}
+
+// Builds a string of the given length from random characters of chars
+func randomString(rng *rand.Rand, chars string, length int) string {
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = chars[rng.Intn(len(chars))]
+	}
+
+	return string(b)
+}
+
+// Builds a grid from rows produced by cell, one per line
+func buildGrid(width, height int, cell func(x, y int) byte) string {
+	var b strings.Builder
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			b.WriteByte(cell(x, y))
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.String()
+}
+
+// Joins the given lines, ending each with a new-line
+func joinLines(lines []string) string {
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Gets n distinct random names of the given length, made from chars, none of which are in exclude.
+func uniqueNames(rng *rand.Rand, n, length int, chars string, exclude ...string) []string {
+	used := map[string]bool{}
+	for _, e := range exclude {
+		used[e] = true
+	}
+
+	var names []string
+	for len(names) < n {
+		name := randomString(rng, chars, length)
+		if !used[name] {
+			used[name] = true
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
@@ -0,0 +1,139 @@
+package gen_test
+
+import (
+	"aoc/day01"
+	"aoc/day02"
+	"aoc/day03"
+	"aoc/day04"
+	"aoc/day05"
+	"aoc/day06"
+	"aoc/day07"
+	"aoc/day08"
+	"aoc/day09"
+	"aoc/day10"
+	"aoc/day11"
+	"aoc/day12"
+	"aoc/day13"
+	"aoc/day14"
+	"aoc/day15"
+	"aoc/day16"
+	"aoc/day17"
+	"aoc/day18"
+	"aoc/day19"
+	"aoc/gen"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Both parts of a day, with answers discarded
+type solver [2]func(string)
+
+func parts[A, B any](partA func(string) A, partB func(string) B) solver {
+	return solver{func(path string) { partA(path) }, func(path string) { partB(path) }}
+}
+
+var solvers = map[int]solver{
+	1:  parts(day01.PartA, day01.PartB),
+	2:  parts(day02.PartA, day02.PartB),
+	3:  parts(day03.PartA, day03.PartB),
+	4:  parts(day04.PartA, day04.PartB),
+	5:  parts(day05.PartA, day05.PartB),
+	6:  parts(day06.PartA, day06.PartB),
+	7:  parts(day07.PartA, day07.PartB),
+	8:  parts(day08.PartA, day08.PartB),
+	9:  parts(day09.PartA, day09.PartB),
+	10: parts(day10.PartA, day10.PartB),
+	11: parts(day11.PartA, day11.PartB),
+	12: parts(day12.PartA, day12.PartB),
+	13: parts(day13.PartA, day13.PartB),
+	14: parts(day14.PartA, day14.PartB),
+	15: parts(day15.PartA, day15.PartB),
+	16: parts(day16.PartA, day16.PartB),
+	17: parts(day17.PartA, day17.PartB),
+	18: parts(day18.PartA, day18.PartB),
+	19: parts(day19.PartA, day19.PartB),
+}
+
+// Extra consistency checks some days have, which generated inputs should pass
+var checks = map[int]func(path string) error{
+	5: func(path string) error {
+		problems, err := day05.ValidateAlmanac(path)
+		if err == nil && len(problems) > 0 {
+			err = fmt.Errorf("%d problems, starting with %v", len(problems), problems[0])
+		}
+		return err
+	},
+	6: day06.CheckWinningHoldTimes,
+	10: func(path string) error {
+		_, err := day10.GetLoopInterior(path)
+		return err
+	},
+	18: func(path string) error {
+		if _, err := day18.ReadDigPlan(path, false); err != nil {
+			return err
+		}
+		_, err := day18.ReadDigPlan(path, true)
+		return err
+	},
+	19: func(path string) error {
+		_, err := day19.SimplifyWorkflows(path)
+		return err
+	},
+}
+
+// Writes a generated input to a temporary file
+func writeInput(t *testing.T, day int, seed int64, size int) string {
+	input, err := gen.Generate(day, seed, size)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	path := filepath.Join(t.TempDir(), fmt.Sprintf("day%02d.txt", day))
+	if err := os.WriteFile(path, []byte(input), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestGeneratedInputsSolve(t *testing.T) {
+	for day := range gen.Generators {
+		for seed := int64(1); seed <= 10; seed++ {
+			day, seed := day, seed
+			t.Run(fmt.Sprintf("day%02d/seed%d", day, seed), func(t *testing.T) {
+				path := writeInput(t, day, seed, 12)
+				defer func() {
+					if r := recover(); r != nil {
+						input, _ := os.ReadFile(path)
+						t.Fatalf("solver panicked: %v\ninput:\n%s", r, input)
+					}
+				}()
+
+				for _, part := range solvers[day] {
+					part(path)
+				}
+				if check, ok := checks[day]; ok {
+					if err := check(path); err != nil {
+						input, _ := os.ReadFile(path)
+						t.Fatalf("%v\ninput:\n%s", err, input)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	for day := range gen.Generators {
+		first, err := gen.Generate(day, 42, 20)
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, _ := gen.Generate(day, 42, 20)
+		if first != second {
+			t.Errorf("day %d: the same seed gave different inputs", day)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package gen
+
+import (
+	"math/rand"
+	"strconv"
+)
+
+// Generates a square engine schematic of numbers of up to three digits, with symbols scattered
+// around them.  Numbers often touch the edges of the grid or each other's symbols.
+func Day03(rng *rand.Rand, size int) string {
+	const symbols = "*#+$/=%@&-"
+
+	rows := make([][]byte, size)
+	for y := range rows {
+		rows[y] = make([]byte, size)
+		for x := 0; x < size; {
+			switch r := rng.Intn(10); {
+			case r < 2:
+				// Numbers always have an empty tile or the edge after them, so they don't run
+				// together
+				num := []byte(strconv.Itoa(between(rng, 1, 999)))
+				if x+len(num) > size {
+					num = num[:size-x]
+				}
+				x += copy(rows[y][x:], num)
+				if x < size {
+					rows[y][x] = '.'
+					x++
+				}
+			case r < 3:
+				rows[y][x] = symbols[rng.Intn(len(symbols))]
+				x++
+			default:
+				rows[y][x] = '.'
+				x++
+			}
+		}
+	}
+
+	return buildGrid(size, size, func(x, y int) byte { return rows[y][x] })
+}
+
+// Generates a square image with about one galaxy per 20 tiles, and a few rows and columns left
+// empty to be expanded.
+func Day11(rng *rand.Rand, size int) string {
+	emptyRows := map[int]bool{}
+	emptyCols := map[int]bool{}
+	for i := 0; i < size/8+1; i++ {
+		emptyRows[rng.Intn(size)] = true
+		emptyCols[rng.Intn(size)] = true
+	}
+
+	return buildGrid(size, size, func(x, y int) byte {
+		if !emptyRows[y] && !emptyCols[x] && rng.Intn(20) == 0 {
+			return '#'
+		}
+		return '.'
+	})
+}
+
+// Generates a square platform of round rocks, cube rocks and empty space.
+func Day14(rng *rand.Rand, size int) string {
+	return buildGrid(size, size, func(x, y int) byte {
+		switch r := rng.Intn(10); {
+		case r < 2:
+			return 'O'
+		case r < 3:
+			return '#'
+		default:
+			return '.'
+		}
+	})
+}
+
+// Generates a square contraption with about one mirror or splitter per 8 tiles.
+func Day16(rng *rand.Rand, size int) string {
+	return buildGrid(size, size, func(x, y int) byte {
+		if rng.Intn(8) == 0 {
+			return "|-/\\"[rng.Intn(4)]
+		}
+		return '.'
+	})
+}
+
+// Options for generating a day 17 heat map
+type Day17Options struct {
+	// Size of the map; both must be at least 5, so the ultra crucible can reach the end
+	Width, Height int
+	// Heat loss of each block is between 1 and this (at most 9)
+	MaxHeat int
+}
+
+// Gets options for a square map of the given size with any heat loss
+func DefaultDay17Options(size int) Day17Options {
+	return Day17Options{Width: max(size, 5), Height: max(size, 5), MaxHeat: 9}
+}
+
+// Generates a map of heat loss values.
+func Day17(rng *rand.Rand, opts Day17Options) string {
+	maxHeat := min(max(opts.MaxHeat, 1), 9)
+	return buildGrid(max(opts.Width, 5), max(opts.Height, 5), func(x, y int) byte {
+		return byte('0' + between(rng, 1, maxHeat))
+	})
+}
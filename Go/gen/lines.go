@@ -0,0 +1,181 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Digits spelled out, for day 1
+var digitWords = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine"}
+
+// Generates calibration lines mixing letters, digits and spelled-out digits, which may overlap
+// (eg. "eightwo").  Every line has at least one real digit, so both parts can be solved.
+func Day01(rng *rand.Rand, size int) string {
+	var lines []string
+	for i := 0; i < size; i++ {
+		var b strings.Builder
+		pieces := between(rng, 1, 8)
+		digitAt := rng.Intn(pieces)
+		for p := 0; p < pieces; p++ {
+			switch {
+			case p == digitAt || rng.Intn(4) == 0:
+				b.WriteByte(byte('1' + rng.Intn(9)))
+			case rng.Intn(2) == 0:
+				word := choose(rng, digitWords)
+				// Sometimes drop the first letter, so words share letters with the one before
+				if b.Len() > 0 && rng.Intn(4) == 0 {
+					word = word[1:]
+				}
+				b.WriteString(word)
+			default:
+				b.WriteString(randomString(rng, "abcdefghijklmnopqrstuvwxyz", between(rng, 1, 4)))
+			}
+		}
+
+		lines = append(lines, b.String())
+	}
+
+	return joinLines(lines)
+}
+
+// Generates games of between one and six draws, each showing some of the red, green and blue
+// cubes.
+func Day02(rng *rand.Rand, size int) string {
+	colors := []string{"red", "green", "blue"}
+
+	var lines []string
+	for id := 1; id <= size; id++ {
+		var draws []string
+		for d := between(rng, 1, 6); d > 0; d-- {
+			var shown []string
+			for _, c := range rng.Perm(len(colors))[:between(rng, 1, len(colors))] {
+				shown = append(shown, fmt.Sprintf("%d %s", between(rng, 1, 20), colors[c]))
+			}
+			draws = append(draws, strings.Join(shown, ", "))
+		}
+
+		lines = append(lines, fmt.Sprintf("Game %d: %s", id, strings.Join(draws, "; ")))
+	}
+
+	return joinLines(lines)
+}
+
+// Generates cards with 10 winning numbers and 25 chosen numbers each.  No card wins more cards
+// than there are left in the table.
+func Day04(rng *rand.Rand, size int) string {
+	const numWinning, numChosen = 10, 25
+
+	var lines []string
+	for id := 1; id <= size; id++ {
+		// Draw every number from the same shuffled pool, so a card can't repeat a number
+		pool := rng.Perm(99)
+		winning := pool[:numWinning]
+		chosen := pool[numWinning : numWinning+numChosen]
+
+		matches := min(rng.Intn(numWinning+1), size-id)
+		copy(chosen, winning[:matches])
+		rng.Shuffle(len(chosen), func(i, j int) { chosen[i], chosen[j] = chosen[j], chosen[i] })
+
+		format := func(nums []int) string {
+			var s []string
+			for _, n := range nums {
+				s = append(s, fmt.Sprintf("%2d", n+1))
+			}
+			return strings.Join(s, " ")
+		}
+
+		lines = append(lines, fmt.Sprintf("Card %3d: %s | %s", id, format(winning), format(chosen)))
+	}
+
+	return joinLines(lines)
+}
+
+// Generates up to four races of up to 99ms each (so the part 2 race still fits in an int), with
+// records that can sometimes not be beaten at all.
+func Day06(rng *rand.Rand, size int) string {
+	races := min(max(size, 1), 4)
+
+	times := "Time:    "
+	distances := "Distance:"
+	for i := 0; i < races; i++ {
+		t := between(rng, 1, 99)
+		best := (t / 2) * (t - t/2)
+		d := between(rng, 0, best)
+
+		times += fmt.Sprintf(" %4d", t)
+		distances += fmt.Sprintf(" %4d", d)
+	}
+
+	return joinLines([]string{times, distances})
+}
+
+// Generates hands of five cards with bids.  Cards are drawn from a small random subset of ranks,
+// so pairs, full houses and jokers come up often.
+func Day07(rng *rand.Rand, size int) string {
+	const ranks = "23456789TJQKA"
+
+	var lines []string
+	for i := 0; i < size; i++ {
+		subset := randomString(rng, ranks, between(rng, 1, 5))
+		lines = append(lines, fmt.Sprintf("%s %d", randomString(rng, subset, 5), between(rng, 1, 1000)))
+	}
+
+	return joinLines(lines)
+}
+
+// Generates sequences of 21 values of polynomials of degree up to 8.  Each sequence is built from
+// the first value at each level of its difference table, so the values are always integers.
+func Day09(rng *rand.Rand, size int) string {
+	const length = 21
+
+	var lines []string
+	for i := 0; i < size; i++ {
+		// firsts[k] is the first value of the kth differences; applying each level's differences
+		// in turn gives the sequence
+		firsts := make([]int, between(rng, 1, 9))
+		for k := range firsts {
+			firsts[k] = between(rng, -5, 5)
+		}
+
+		level := make([]int, length)
+		for k := len(firsts) - 1; k >= 0; k-- {
+			next := make([]int, length)
+			next[0] = firsts[k]
+			for j := 1; j < length; j++ {
+				next[j] = next[j-1] + level[j-1]
+			}
+			level = next
+		}
+
+		var nums []string
+		for _, v := range level {
+			nums = append(nums, strconv.Itoa(v))
+		}
+		lines = append(lines, strings.Join(nums, " "))
+	}
+
+	return joinLines(lines)
+}
+
+// Generates an initialization sequence of steps on a pool of labels, so labels are often removed
+// and re-added.  The sequence is a single line with no new-line at the end.
+func Day15(rng *rand.Rand, size int) string {
+	labels := uniqueNames(rng, min(max(size/4, 1), 500), 2, "abcdefghijklmnopqrstuvwxyz")
+	for i := range labels {
+		labels[i] += randomString(rng, "abcdefghijklmnopqrstuvwxyz", rng.Intn(5))
+	}
+
+	var steps []string
+	for i := 0; i < size; i++ {
+		label := choose(rng, labels)
+		if rng.Intn(3) == 0 {
+			steps = append(steps, label+"-")
+		} else {
+			steps = append(steps, fmt.Sprintf("%s=%d", label, between(rng, 1, 9)))
+		}
+	}
+
+	return strings.Join(steps, ",")
+}
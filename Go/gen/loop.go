@@ -0,0 +1,271 @@
+package gen
+
+import (
+	"aoc/utils"
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Generates a random simple loop through the tiles of a (2 * cols) x (2 * rows) grid, returned as
+// the tiles in the order the loop visits them.  The loop starts on a corner, so the direction
+// changes between the last tile and the first.
+//
+// We grow a random tree over a cols x rows grid of blocks, until it covers about fill of them.
+// Each block in the tree is 2x2 tiles, and the loop is the outline of the tree, walking round it
+// through those tiles; it winds in and out of the gaps between branches, and when branches meet
+// up around blocks outside the tree, it goes round those too, leaving holes in the middle.
+func randomLoop(rng *rand.Rand, cols, rows int, fill float64) []utils.Point {
+	inTree := map[utils.Point]bool{}
+	type edge struct{ From, To utils.Point }
+	var treeEdges, frontier []edge
+
+	add := func(block utils.Point) {
+		inTree[block] = true
+		for _, d := range utils.CARDINAL_DIRS_CLOCKWISE {
+			next := block.Add(d)
+			if next.X >= 0 && next.X < cols && next.Y >= 0 && next.Y < rows && !inTree[next] {
+				frontier = append(frontier, edge{block, next})
+			}
+		}
+	}
+
+	add(utils.Point{X: rng.Intn(cols), Y: rng.Intn(rows)})
+	target := max(int(fill*float64(cols*rows)), 1)
+	for len(inTree) < target && len(frontier) > 0 {
+		i := rng.Intn(len(frontier))
+		e := frontier[i]
+		frontier[i] = frontier[len(frontier)-1]
+		frontier = frontier[:len(frontier)-1]
+
+		if !inTree[e.To] {
+			treeEdges = append(treeEdges, e)
+			add(e.To)
+		}
+	}
+
+	// Start with a little loop round each block, then join the loops of blocks joined in the tree
+	type link struct{ A, B utils.Point }
+	links := map[link]bool{}
+	corner := func(block utils.Point, dx, dy int) utils.Point {
+		return utils.Point{X: 2*block.X + dx, Y: 2*block.Y + dy}
+	}
+	for block := range inTree {
+		links[link{corner(block, 0, 0), corner(block, 1, 0)}] = true
+		links[link{corner(block, 0, 1), corner(block, 1, 1)}] = true
+		links[link{corner(block, 0, 0), corner(block, 0, 1)}] = true
+		links[link{corner(block, 1, 0), corner(block, 1, 1)}] = true
+	}
+	for _, e := range treeEdges {
+		// Make sure From is above or left of To
+		from, to := e.From, e.To
+		if to.X < from.X || to.Y < from.Y {
+			from, to = to, from
+		}
+
+		if to.X > from.X {
+			delete(links, link{corner(from, 1, 0), corner(from, 1, 1)})
+			delete(links, link{corner(to, 0, 0), corner(to, 0, 1)})
+			links[link{corner(from, 1, 0), corner(to, 0, 0)}] = true
+			links[link{corner(from, 1, 1), corner(to, 0, 1)}] = true
+		} else {
+			delete(links, link{corner(from, 0, 1), corner(from, 1, 1)})
+			delete(links, link{corner(to, 0, 0), corner(to, 1, 0)})
+			links[link{corner(from, 0, 1), corner(to, 0, 0)}] = true
+			links[link{corner(from, 1, 1), corner(to, 1, 0)}] = true
+		}
+	}
+
+	neighbors := map[utils.Point][]utils.Point{}
+	for l := range links {
+		neighbors[l.A] = append(neighbors[l.A], l.B)
+		neighbors[l.B] = append(neighbors[l.B], l.A)
+	}
+
+	// The top-most then left-most tile is a corner of the loop, leading right and down
+	start := utils.Point{X: 2 * cols, Y: 2 * rows}
+	for p := range neighbors {
+		if p.Y < start.Y || (p.Y == start.Y && p.X < start.X) {
+			start = p
+		}
+	}
+
+	loop := []utils.Point{start}
+	prev, cur := start, start.Add(utils.RIGHT)
+	for cur != start {
+		loop = append(loop, cur)
+		next := neighbors[cur][0]
+		if next == prev {
+			next = neighbors[cur][1]
+		}
+		prev, cur = cur, next
+	}
+
+	return loop
+}
+
+// Options for generating a day 10 field of pipes
+type Day10Options struct {
+	// Size of the field in tiles; odd sizes leave the last row or column off the loop
+	Width, Height int
+	// Share of the field the loop winds around
+	Fill float64
+	// Chance each tile off the loop holds a pipe, rather than being ground
+	Junk float64
+}
+
+// Gets options for a square field of the given size, about half taken up by the loop
+func DefaultDay10Options(size int) Day10Options {
+	return Day10Options{Width: max(size, 2), Height: max(size, 2), Fill: 0.5, Junk: 0.6}
+}
+
+// Pipe connecting each pair of directions
+var pipeChars = map[[2]utils.Point]byte{
+	{utils.UP, utils.DOWN}:    '|',
+	{utils.LEFT, utils.RIGHT}: '-',
+	{utils.UP, utils.RIGHT}:   'L',
+	{utils.UP, utils.LEFT}:    'J',
+	{utils.DOWN, utils.LEFT}:  '7',
+	{utils.DOWN, utils.RIGHT}: 'F',
+}
+
+// Gets the pipe connecting the given directions, in either order
+func getPipe(d1, d2 utils.Point) byte {
+	if c, ok := pipeChars[[2]utils.Point{d1, d2}]; ok {
+		return c
+	}
+	return pipeChars[[2]utils.Point{d2, d1}]
+}
+
+// Generates a field with a single loop of pipe through S, surrounded by junk pipes.  The pipes next
+// to S only connect to it if they're part of the loop.
+func Day10(rng *rand.Rand, opts Day10Options) string {
+	width, height := max(opts.Width, 2), max(opts.Height, 2)
+	loop := randomLoop(rng, width/2, height/2, opts.Fill)
+
+	grid := utils.GridFromDimensions[byte](width, height)
+	onLoop := map[utils.Point]bool{}
+	for i, p := range loop {
+		prev := loop[(i+len(loop)-1)%len(loop)]
+		next := loop[(i+1)%len(loop)]
+		grid.Set(p, getPipe(prev.Sub(p), next.Sub(p)))
+		onLoop[p] = true
+	}
+
+	const junk = "|-LJ7F"
+	for i := range grid.Slice {
+		if !onLoop[grid.PosFromIndex(i)] {
+			grid.Slice[i] = '.'
+			if rng.Float64() < opts.Junk {
+				grid.Slice[i] = junk[rng.Intn(len(junk))]
+			}
+		}
+	}
+
+	start := choose(rng, loop)
+	grid.Set(start, 'S')
+	for _, d := range utils.CARDINAL_DIRS_CLOCKWISE {
+		n := start.Add(d)
+		if grid.Contains(n) && !onLoop[n] {
+			grid.Set(n, '.')
+		}
+	}
+
+	return buildGrid(width, height, func(x, y int) byte { return grid.GetCopy(utils.Point{X: x, Y: y}) })
+}
+
+// Options for generating a day 18 dig plan
+type Day18Options struct {
+	// Size of the grid of blocks the trench winds around, as for day 10
+	Cols, Rows int
+	// Share of the grid the trench winds around
+	Fill float64
+	// Each step of the loop digs between 2 and this many meters in the plain instructions
+	MaxStep int
+	// Likewise for the hex-encoded instructions; the total for one instruction must fit in five
+	// hex digits
+	MaxHexStep int
+}
+
+// Gets options for a plan winding around a grid of the given size
+func DefaultDay18Options(size int) Day18Options {
+	return Day18Options{Cols: max(size/2, 1), Rows: max(size/2, 1), Fill: 0.5, MaxStep: 5, MaxHexStep: 10000}
+}
+
+// A single dig instruction
+type digStep struct {
+	Dir      utils.Point
+	Distance int
+}
+
+// Turns a loop of tiles into dig instructions, stretching it by giving each column and row of
+// tiles a random width between 2 and maxStep.  Since the widths are all positive, the stretched
+// loop still never crosses itself.
+//
+// The loop often runs right alongside itself, so widths of 1 would leave bits of trench touching.
+// Real inputs never do that, and it makes the puzzle ambiguous: a pocket of ground shut in
+// between two touching bits of trench is outside the loop, but can't be reached from outside.
+func loopToPlan(rng *rand.Rand, loop []utils.Point, maxStep int) []digStep {
+	gapsX := map[int]int{}
+	gapsY := map[int]int{}
+	// Gets the width of the gap between two neighbouring rows or columns, starting at the lower
+	gap := func(gaps map[int]int, lower int) int {
+		if _, ok := gaps[lower]; !ok {
+			gaps[lower] = between(rng, 2, maxStep)
+		}
+		return gaps[lower]
+	}
+
+	var plan []digStep
+	for i, p := range loop {
+		next := loop[(i+1)%len(loop)]
+		dir := next.Sub(p)
+
+		var dist int
+		if dir.X != 0 {
+			dist = gap(gapsX, min(p.X, next.X))
+		} else {
+			dist = gap(gapsY, min(p.Y, next.Y))
+		}
+
+		if len(plan) > 0 && plan[len(plan)-1].Dir == dir {
+			plan[len(plan)-1].Distance += dist
+		} else {
+			plan = append(plan, digStep{dir, dist})
+		}
+	}
+
+	return plan
+}
+
+// Generates a dig plan whose trench is a simple loop for both the plain and the hex-encoded
+// instructions.  The hex instructions follow the same loop, transposed half the time and stretched
+// differently.
+func Day18(rng *rand.Rand, opts Day18Options) string {
+	cols, rows := max(opts.Cols, 1), max(opts.Rows, 1)
+	loop := randomLoop(rng, cols, rows, opts.Fill)
+
+	// Keep each hex instruction within five hex digits; an instruction crosses at most the whole
+	// stretched grid
+	maxHexStep := max(min(opts.MaxHexStep, 0xfffff/(2*max(cols, rows))), 2)
+
+	plain := loopToPlan(rng, loop, max(opts.MaxStep, 2))
+	transposed := loop
+	if rng.Intn(2) == 0 {
+		transposed = make([]utils.Point, len(loop))
+		for i, p := range loop {
+			transposed[i] = utils.Point{X: p.Y, Y: p.X}
+		}
+	}
+	hex := loopToPlan(rng, transposed, maxHexStep)
+
+	dirNames := map[utils.Point]string{utils.RIGHT: "R", utils.DOWN: "D", utils.LEFT: "L", utils.UP: "U"}
+	dirDigits := map[utils.Point]int{utils.RIGHT: 0, utils.DOWN: 1, utils.LEFT: 2, utils.UP: 3}
+
+	var lines []string
+	for i := range plain {
+		lines = append(lines, fmt.Sprintf("%s %d (#%05x%d)", dirNames[plain[i].Dir], plain[i].Distance, hex[i].Distance, dirDigits[hex[i].Dir]))
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
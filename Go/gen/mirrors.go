@@ -0,0 +1,125 @@
+package gen
+
+import (
+	"math/rand"
+	"strings"
+)
+
+// Options for generating day 13 patterns
+type Day13Options struct {
+	// Number of patterns
+	Patterns int
+	// Patterns are between 3 and this many rows and columns
+	MaxSize int
+}
+
+// Gets options for the given number of patterns up to the size of the real input's
+func DefaultDay13Options(size int) Day13Options {
+	return Day13Options{Patterns: size, MaxSize: 17}
+}
+
+// Counts the cells which don't match their reflection in the line between rows line-1 and line
+func countRowMismatches(pattern [][]byte, line int) int {
+	count := 0
+	for k := 0; line-1-k >= 0 && line+k < len(pattern); k++ {
+		for x := range pattern[0] {
+			if pattern[line-1-k][x] != pattern[line+k][x] {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// Gets the pattern flipped about its diagonal, so columns become rows
+func transpose(pattern [][]byte) [][]byte {
+	result := make([][]byte, len(pattern[0]))
+	for x := range result {
+		result[x] = make([]byte, len(pattern))
+		for y := range pattern {
+			result[x][y] = pattern[y][x]
+		}
+	}
+
+	return result
+}
+
+// Counts the lines of reflection, both between rows and between columns, with the given number of
+// mismatched cells
+func countLines(pattern [][]byte, mismatches int) int {
+	count := 0
+	for _, p := range [][][]byte{pattern, transpose(pattern)} {
+		for line := 1; line < len(p); line++ {
+			if countRowMismatches(p, line) == mismatches {
+				count++
+			}
+		}
+	}
+
+	return count
+}
+
+// Generates a pattern with exactly one line of reflection, and exactly one smudge which, when
+// fixed, gives exactly one different line.
+//
+// We make a pattern that reflects about both a line between rows and a line between columns, then
+// flip one cell which breaks the row reflection but not the column one.  Random patterns can
+// reflect by chance too, so we try again until the lines are unique.
+func mirrorPattern(rng *rand.Rand, maxSize int) [][]byte {
+	for {
+		width, height := between(rng, 3, maxSize), between(rng, 3, maxSize)
+		pattern := make([][]byte, height)
+		for y := range pattern {
+			pattern[y] = []byte(randomString(rng, ".#", width))
+		}
+
+		col, row := between(rng, 1, width-1), between(rng, 1, height-1)
+		for y := range pattern {
+			for k := 0; col-1-k >= 0 && col+k < width; k++ {
+				pattern[y][col+k] = pattern[y][col-1-k]
+			}
+		}
+		for k := 0; row-1-k >= 0 && row+k < height; k++ {
+			copy(pattern[row+k], pattern[row-1-k])
+		}
+
+		// The smudge must be in a column outside the column reflection, and a row inside the row
+		// reflection
+		var xs []int
+		for x := 0; x < width; x++ {
+			if x < 2*col-width || x >= 2*col {
+				xs = append(xs, x)
+			}
+		}
+		if len(xs) == 0 {
+			continue
+		}
+		y := between(rng, max(2*row-height, 0), min(2*row, height)-1)
+		x := choose(rng, xs)
+		pattern[y][x] ^= '.' ^ '#'
+
+		if rng.Intn(2) == 0 {
+			pattern = transpose(pattern)
+		}
+		if countLines(pattern, 0) == 1 && countLines(pattern, 1) == 1 {
+			return pattern
+		}
+	}
+}
+
+// Generates patterns of ash and rocks, each with one line of reflection and one smudge.
+func Day13(rng *rand.Rand, opts Day13Options) string {
+	maxSize := max(opts.MaxSize, 3)
+
+	var patterns []string
+	for i := 0; i < opts.Patterns; i++ {
+		var rows []string
+		for _, r := range mirrorPattern(rng, maxSize) {
+			rows = append(rows, string(r))
+		}
+		patterns = append(patterns, joinLines(rows))
+	}
+
+	return strings.Join(patterns, "\n")
+}
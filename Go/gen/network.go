@@ -0,0 +1,90 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Options for generating a day 8 network
+type Day08Options struct {
+	// Number of ghosts; the first starts at AAA and ends at ZZZ, for part 1
+	Ghosts int
+	// Each ghost's loop is between 2 and this many steps long
+	MaxPeriod int
+	// Length of the list of left/right directions
+	Directions int
+	// Most nodes at any one step along a ghost's loop; with more than one, the directions decide
+	// which of them the ghost is on
+	Width int
+}
+
+// Gets options for a network of six ghosts whose loops are around the given length
+func DefaultDay08Options(size int) Day08Options {
+	return Day08Options{Ghosts: 6, MaxPeriod: max(size, 2), Directions: max(size/2, 1), Width: 2}
+}
+
+// Generates a network in which every ghost is guaranteed to reach an end node at the same time.
+//
+// Each ghost has its own nodes, arranged in levels: its start node, then levels 1 to period-1
+// which hold up to Width nodes each, then its end node.  Both directions from a node lead to
+// some node on the next level, and the end node leads back to level 1.  So whichever way the
+// directions go, a ghost is on its end node exactly every period steps, and all the ghosts line
+// up at the LCM of their periods.
+func Day08(rng *rand.Rand, opts Day08Options) string {
+	ghosts := min(max(opts.Ghosts, 1), 26)
+	maxPeriod := max(opts.MaxPeriod, 2)
+	width := max(opts.Width, 1)
+
+	// Names ending in A or Z mark starts and ends, so other nodes must avoid them
+	const letters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	used := map[string]bool{"AAA": true, "ZZZ": true}
+	newName := func(lastLetters string) string {
+		for {
+			name := randomString(rng, letters, 2) + randomString(rng, lastLetters, 1)
+			if !used[name] {
+				used[name] = true
+				return name
+			}
+		}
+	}
+
+	var lines []string
+	for g := 0; g < ghosts; g++ {
+		start, end := "AAA", "ZZZ"
+		if g > 0 {
+			start, end = newName("A"), newName("Z")
+		}
+
+		// levels[0] is the start and levels[period] the end
+		period := between(rng, 2, maxPeriod)
+		levels := [][]string{{start}}
+		for l := 1; l < period; l++ {
+			var level []string
+			for n := between(rng, 1, width); n > 0; n-- {
+				level = append(level, newName("BCDEFGHIJKLMNOPQRSTUVWXY"))
+			}
+			levels = append(levels, level)
+		}
+		levels = append(levels, []string{end})
+
+		for l, level := range levels {
+			next := levels[1]
+			if l+1 < len(levels) {
+				next = levels[l+1]
+			}
+			for _, node := range level {
+				lines = append(lines, fmt.Sprintf("%s = (%s, %s)", node, choose(rng, next), choose(rng, next)))
+			}
+		}
+	}
+
+	rng.Shuffle(len(lines), func(i, j int) { lines[i], lines[j] = lines[j], lines[i] })
+
+	var dirs strings.Builder
+	for i := 0; i < max(opts.Directions, 1); i++ {
+		dirs.WriteByte("LR"[rng.Intn(2)])
+	}
+
+	return dirs.String() + "\n\n" + joinLines(lines)
+}
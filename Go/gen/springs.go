@@ -0,0 +1,86 @@
+package gen
+
+import (
+	"math/rand"
+	"strconv"
+	"strings"
+)
+
+// Options for generating day 12 spring records
+type Day12Options struct {
+	// Number of rows
+	Rows int
+	// Most springs in a row.  The real input stops at 20; much more than that and unfolded rows can
+	// have too many arrangements to count in an int.
+	MaxLength int
+	// Chance each spring starts a run of unknown springs
+	Unknown float64
+	// Runs of unknown springs are between 1 and this long
+	MaxRun int
+}
+
+// Gets options for the given number of rows like the real input, with some long runs of unknowns
+func DefaultDay12Options(size int) Day12Options {
+	return Day12Options{Rows: size, MaxLength: 20, Unknown: 0.25, MaxRun: 8}
+}
+
+// Generates rows of springs with their damaged groups.  Each row is made by laying out the groups
+// for real, then hiding some springs, so there's always at least one arrangement.
+func Day12(rng *rand.Rand, opts Day12Options) string {
+	maxLength := max(opts.MaxLength, 1)
+	maxRun := max(opts.MaxRun, 1)
+
+	var lines []string
+	for i := 0; i < opts.Rows; i++ {
+		length := between(rng, 1, maxLength)
+
+		// Pick groups until there's no room for another, leaving a gap between each
+		var groups []int
+		used := 0
+		for {
+			room := length - used
+			if len(groups) > 0 {
+				room--
+			}
+			if room < 1 || (len(groups) > 0 && rng.Intn(3) == 0) {
+				break
+			}
+
+			g := between(rng, 1, min(room, 6))
+			if len(groups) > 0 {
+				used++
+			}
+			used += g
+			groups = append(groups, g)
+		}
+
+		// Share the spare operational springs out between the gaps, including either end
+		gaps := make([]int, len(groups)+1)
+		for j := 1; j < len(groups); j++ {
+			gaps[j] = 1
+		}
+		for spare := length - used; spare > 0; spare-- {
+			gaps[rng.Intn(len(gaps))]++
+		}
+
+		springs := []byte(strings.Repeat(".", gaps[0]))
+		var groupStrs []string
+		for j, g := range groups {
+			springs = append(springs, strings.Repeat("#", g)...)
+			springs = append(springs, strings.Repeat(".", gaps[j+1])...)
+			groupStrs = append(groupStrs, strconv.Itoa(g))
+		}
+
+		for j := 0; j < len(springs); j++ {
+			if rng.Float64() < opts.Unknown {
+				for end := min(j+between(rng, 1, maxRun), len(springs)); j < end; j++ {
+					springs[j] = '?'
+				}
+			}
+		}
+
+		lines = append(lines, string(springs)+" "+strings.Join(groupStrs, ","))
+	}
+
+	return joinLines(lines)
+}
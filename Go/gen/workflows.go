@@ -0,0 +1,83 @@
+package gen
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+)
+
+// Options for generating a day 19 system of workflows and parts
+type Day19Options struct {
+	// Length of the longest chain of workflows from "in"; at least one chain is this long
+	Depth int
+	// Workflows have between 1 and this many rules, plus their default
+	MaxRules int
+	// Chance each rule sends parts to another workflow, rather than accepting or rejecting them
+	Branch float64
+	// Number of parts
+	Parts int
+}
+
+// Gets options for a tree of workflows like the real input's, with the given number of parts
+func DefaultDay19Options(size int) Day19Options {
+	return Day19Options{Depth: 8, MaxRules: 3, Branch: 0.4, Parts: size}
+}
+
+// Generates a tree of workflows, starting at "in", and parts with ratings from 1 to 4000.  Every
+// workflow is reached from exactly one other, so there are no cycles.
+func Day19(rng *rand.Rand, opts Day19Options) string {
+	depth := max(opts.Depth, 1)
+	maxRules := max(opts.MaxRules, 1)
+	used := map[string]bool{"in": true}
+
+	newName := func() string {
+		for {
+			name := randomString(rng, "abcdefghijklmnopqrstuvwxyz", between(rng, 2, 3))
+			if !used[name] {
+				used[name] = true
+				return name
+			}
+		}
+	}
+
+	var workflows []string
+	// Adds the named workflow, and the workflows it sends parts to.  If onChain is set, the workflow
+	// carries on the chain to the full depth, through a random one of its destinations.
+	var addWorkflow func(name string, level int, onChain bool)
+	addWorkflow = func(name string, level int, onChain bool) {
+		numRules := between(rng, 1, maxRules)
+		chainDest := -1
+		if onChain && level < depth {
+			chainDest = rng.Intn(numRules + 1)
+		}
+
+		var dests []string
+		for i := 0; i <= numRules; i++ {
+			if level < depth && (i == chainDest || rng.Float64() < opts.Branch) {
+				child := newName()
+				addWorkflow(child, level+1, i == chainDest)
+				dests = append(dests, child)
+			} else {
+				dests = append(dests, choose(rng, []string{"A", "R"}))
+			}
+		}
+
+		var rules []string
+		for _, dst := range dests[:numRules] {
+			rules = append(rules, fmt.Sprintf("%c%c%d:%s", "xmas"[rng.Intn(4)], "<>"[rng.Intn(2)], between(rng, 1, 4000), dst))
+		}
+		rules = append(rules, dests[numRules])
+
+		workflows = append(workflows, fmt.Sprintf("%s{%s}", name, strings.Join(rules, ",")))
+	}
+	addWorkflow("in", 1, true)
+
+	rng.Shuffle(len(workflows), func(i, j int) { workflows[i], workflows[j] = workflows[j], workflows[i] })
+
+	var parts []string
+	for i := 0; i < opts.Parts; i++ {
+		parts = append(parts, fmt.Sprintf("{x=%d,m=%d,a=%d,s=%d}", between(rng, 1, 4000), between(rng, 1, 4000), between(rng, 1, 4000), between(rng, 1, 4000)))
+	}
+
+	return joinLines(workflows) + "\n" + joinLines(parts)
+}
@@ -0,0 +1,9 @@
+// Checks every day's answers for its sample input against expected ("golden") values stored next
+// to the input, in inputs/dayNN_sample.golden.  Run with -update to rewrite the golden files from
+// the current answers after a deliberate change:
+//
+//	go test ./golden -update
+//
+// Each golden file has a line per part, such as "A: 142".  A part can be skipped with a reason
+// instead, such as "A: skip: the sample is for part B only"; -update leaves those lines alone.
+package golden
@@ -0,0 +1,235 @@
+package golden
+
+import (
+	"aoc/day01"
+	"aoc/day02"
+	"aoc/day03"
+	"aoc/day04"
+	"aoc/day05"
+	"aoc/day06"
+	"aoc/day07"
+	"aoc/day08"
+	"aoc/day09"
+	"aoc/day10"
+	"aoc/day11"
+	"aoc/day12"
+	"aoc/day13"
+	"aoc/day14"
+	"aoc/day15"
+	"aoc/day16"
+	"aoc/day17"
+	"aoc/day18"
+	"aoc/day19"
+	"aoc/day20"
+	"aoc/day21"
+	"aoc/day22"
+	"aoc/day23"
+	"aoc/day24"
+	"aoc/day25"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var update = flag.Bool("update", false, "Rewrite the golden files with the current answers.")
+
+// How long a single part may run for before it's considered stuck
+const partTimeout = 30 * time.Second
+
+// The answer stubbed-out days give, which marks them as pending
+const notImplemented = "Not implemented"
+
+// Both parts of a day, with answers formatted as strings
+type solver struct {
+	Parts [2]func(string) string
+}
+
+func parts[A, B any](partA func(string) A, partB func(string) B) solver {
+	return solver{[2]func(string) string{
+		func(path string) string { return fmt.Sprint(partA(path)) },
+		func(path string) string { return fmt.Sprint(partB(path)) },
+	}}
+}
+
+var solvers = map[int]solver{
+	1:  parts(day01.PartA, day01.PartB),
+	2:  parts(day02.PartA, day02.PartB),
+	3:  parts(day03.PartA, day03.PartB),
+	4:  parts(day04.PartA, day04.PartB),
+	5:  parts(day05.PartA, day05.PartB),
+	6:  parts(day06.PartA, day06.PartB),
+	7:  parts(day07.PartA, day07.PartB),
+	8:  parts(day08.PartA, day08.PartB),
+	9:  parts(day09.PartA, day09.PartB),
+	10: parts(day10.PartA, day10.PartB),
+	11: parts(day11.PartA, day11.PartB),
+	12: parts(day12.PartA, day12.PartB),
+	13: parts(day13.PartA, day13.PartB),
+	14: parts(day14.PartA, day14.PartB),
+	15: parts(day15.PartA, day15.PartB),
+	16: parts(day16.PartA, day16.PartB),
+	17: parts(day17.PartA, day17.PartB),
+	18: parts(day18.PartA, day18.PartB),
+	19: parts(day19.PartA, day19.PartB),
+	20: parts(day20.PartA, day20.PartB),
+	21: parts(day21.PartA, day21.PartB),
+	22: parts(day22.PartA, day22.PartB),
+	23: parts(day23.PartA, day23.PartB),
+	24: parts(day24.PartA, day24.PartB),
+	25: parts(day25.PartA, day25.PartB),
+}
+
+var partNames = [2]string{"A", "B"}
+
+// Gets the expected value of each part from a golden file, keyed by part name.  Missing files give
+// no values.
+func readGolden(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]string{}
+	for i, line := range strings.Split(strings.TrimRight(string(data), "\r\n"), "\n") {
+		name, value, ok := strings.Cut(strings.TrimRight(line, "\r"), ": ")
+		if !ok || !slices.Contains(partNames[:], name) {
+			return nil, fmt.Errorf("%s:%d: expected \"A: value\" or \"B: value\"", path, i+1)
+		}
+		values[name] = value
+	}
+
+	return values, nil
+}
+
+func writeGolden(path string, values map[string]string) error {
+	var s strings.Builder
+	for _, name := range partNames {
+		if v, ok := values[name]; ok {
+			fmt.Fprintf(&s, "%s: %s\n", name, v)
+		}
+	}
+
+	return os.WriteFile(path, []byte(s.String()), 0644)
+}
+
+// Runs a part, turning panics (which is how solvers report errors) and hangs into errors.
+func runPart(part func(string) string, path string) (string, error) {
+	type result struct {
+		answer string
+		err    error
+	}
+
+	done := make(chan result, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- result{err: fmt.Errorf("panicked: %v", r)}
+			}
+		}()
+		done <- result{answer: part(path)}
+	}()
+
+	select {
+	case r := <-done:
+		return r.answer, r.err
+	case <-time.After(partTimeout):
+		// There's no stopping the goroutine, but the test run ends soon enough
+		return "", fmt.Errorf("did not finish within %v", partTimeout)
+	}
+}
+
+// Gets the day number from a sample input's file name
+var sampleRegex = regexp.MustCompile(`^day(\d\d)_sample\.txt$`)
+
+// Finds the sample inputs, keyed by day.
+func findSamples(t *testing.T) map[int]string {
+	paths, err := filepath.Glob("../inputs/day*_sample.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	samples := map[int]string{}
+	for _, p := range paths {
+		m := sampleRegex.FindStringSubmatch(filepath.Base(p))
+		if m == nil {
+			continue
+		}
+
+		day, _ := strconv.Atoi(m[1])
+		samples[day] = p
+	}
+
+	return samples
+}
+
+func TestSamples(t *testing.T) {
+	samples := findSamples(t)
+	for day := range samples {
+		if _, ok := solvers[day]; !ok {
+			t.Errorf("sample input for day %d has no registered solver", day)
+		}
+	}
+
+	for day := 1; day <= 25; day++ {
+		s := solvers[day]
+		t.Run(fmt.Sprintf("day%02d", day), func(t *testing.T) {
+			input, ok := samples[day]
+			if !ok {
+				t.Skip("pending: no sample input")
+			}
+
+			goldenPath := strings.TrimSuffix(input, ".txt") + ".golden"
+			golden, err := readGolden(goldenPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			changed := false
+			for i, name := range partNames {
+				t.Run(name, func(t *testing.T) {
+					expected, hasExpected := golden[name]
+					if reason, ok := strings.CutPrefix(expected, "skip: "); ok {
+						t.Skip(reason)
+					}
+
+					answer, err := runPart(s.Parts[i], input)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if answer == notImplemented {
+						t.Skip("pending: not implemented")
+					}
+
+					switch {
+					case *update:
+						if answer != expected {
+							golden[name] = answer
+							changed = true
+						}
+					case !hasExpected:
+						t.Errorf("got %s, but %s has no expected value; run with -update to record it", answer, goldenPath)
+					case answer != expected:
+						t.Errorf("got %s, want %s", answer, expected)
+					}
+				})
+			}
+
+			if changed {
+				if err := writeGolden(goldenPath, golden); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
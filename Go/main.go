@@ -26,8 +26,11 @@ import (
 	"aoc/day23"
 	"aoc/day24"
 	"aoc/day25"
+	"aoc/utils"
+	"aoc/utils/vis"
 	"flag"
 	"fmt"
+	"os"
 )
 
 func printResult[T1 any, T2 any](day int, sample bool, partA T1, partB T2) {
@@ -41,13 +44,21 @@ func printResult[T1 any, T2 any](day int, sample bool, partA T1, partB T2) {
 	fmt.Printf("\n"+formatB+"\n%v\n", day, partB)
 }
 
-func runCode(day int, sample bool) {
-	dayStr := fmt.Sprintf("%02d", day)
-	file := "inputs/day" + dayStr
+// Number of workers to use for puzzles which support running in parallel; 0 runs them sequentially
+var workers int
+
+// Gets the path to the input file for the given day
+func getInputPath(day int, sample bool) string {
+	file := fmt.Sprintf("inputs/day%02d", day)
 	if sample {
 		file += "_sample"
 	}
-	file += ".txt"
+
+	return file + ".txt"
+}
+
+func runCode(day int, sample bool) {
+	file := getInputPath(day, sample)
 
 	switch day {
 	case 1:
@@ -81,7 +92,11 @@ func runCode(day int, sample bool) {
 	case 15:
 		printResult(day, sample, day15.PartA(file), day15.PartB(file))
 	case 16:
-		printResult(day, sample, day16.PartA(file), day16.PartB(file))
+		if workers > 0 {
+			printResult(day, sample, day16.PartA(file), day16.PartBParallel(file, workers))
+		} else {
+			printResult(day, sample, day16.PartA(file), day16.PartB(file))
+		}
 	case 17:
 		printResult(day, sample, day17.PartA(file), day17.PartB(file))
 	case 18:
@@ -105,11 +120,79 @@ func runCode(day int, sample bool) {
 	}
 }
 
+// Records the given day's simulation frame by frame.
+func recordVisualization(day int, sample bool) *vis.Recording {
+	file := getInputPath(day, sample)
+
+	rec := &vis.Recording{}
+	var err error
+	switch day {
+	case 10:
+		err = day10.Visualize(file, rec)
+	case 14:
+		err = day14.Visualize(file, rec)
+	case 16:
+		err = day16.Visualize(file, rec)
+	case 17:
+		err = day17.Visualize(file, rec)
+	default:
+		panic("Visualization not supported for this day.")
+	}
+	utils.CheckError(err)
+
+	return rec
+}
+
+// Plays the given day's simulation back in the terminal.
+func runVisualization(day int, sample bool, fps int) {
+	rec := recordVisualization(day, sample)
+
+	// Playback controls need keypresses as they're typed; if we can't get them, just play through
+	var keys <-chan byte
+	if restore, err := vis.RawInput(); err == nil {
+		defer restore()
+		keys = vis.ReadKeys(os.Stdin)
+	}
+
+	utils.CheckError(vis.Play(rec, os.Stdout, keys, vis.PlayOptions{FPS: fps}))
+}
+
+// Renders the given day's simulation to a GIF or PNG frames.
+func runRender(day int, sample bool, path string, fps, cellSize int, palette string) {
+	rec := recordVisualization(day, sample)
+
+	opts := vis.DefaultRenderOptions()
+	opts.CellSize = cellSize
+	// GIF delays are in hundredths of a second
+	opts.Delay = max(100/max(fps, 1), 1)
+	if palette != "" {
+		p, err := vis.ParsePalette(palette)
+		utils.CheckError(err)
+
+		opts.Palette = p
+	}
+
+	utils.CheckError(vis.RenderToFile(path, rec, opts))
+}
+
 func main() {
 	dayPtr := flag.Int("d", 1, "The day to run.")
 	samplePtr := flag.Bool("s", false, "When set, runs with the sample input instead of the real input.")
+	flag.IntVar(&workers, "workers", 0, "When above 0, runs puzzles which support it (day 16) in parallel with this many workers.")
+
+	visPtr := flag.Bool("vis", false, "When set, plays an animation of the day's simulation in the terminal instead of solving it (days 10, 14, 16 and 17).")
+	fpsPtr := flag.Int("fps", 30, "Frames per second to start animations at.")
+	renderPtr := flag.String("render", "", "When set, renders the day's simulation to this .gif file (or numbered .png files) instead of solving it (days 10, 14, 16 and 17).")
+	cellPtr := flag.Int("cell", 4, "Size in pixels of each grid cell when rendering.")
+	palettePtr := flag.String("palette", "", "Colours of each character when rendering, as comma-separated char=RRGGBB pairs; eg. \"#=606070,O=d0d0d0\".")
 
 	flag.Parse()
 
-	runCode(*dayPtr, *samplePtr)
+	if *renderPtr != "" {
+		runRender(*dayPtr, *samplePtr, *renderPtr, *fpsPtr, *cellPtr, *palettePtr)
+	} else if *visPtr {
+		runVisualization(*dayPtr, *samplePtr, *fpsPtr)
+	} else {
+		runCode(*dayPtr, *samplePtr)
+	}
 }
@@ -0,0 +1,144 @@
+// Compares two sets of `go test -bench` output and flags benchmarks which got slower.
+//
+// Usage:
+//
+//	go test -bench . ./... > old.txt
+//	(make changes)
+//	go test -bench . ./... > new.txt
+//	go run ./tools/benchcmp -threshold 10 old.txt new.txt
+//
+// When a benchmark was run several times (eg. with -count), the median time is used.  Exits with
+// status 1 if any benchmark regressed by more than the threshold.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Matches a benchmark result line; eg. "BenchmarkDay/PartA-8   	  100	  16477 ns/op"
+var resultRegex = regexp.MustCompile(`^(Benchmark\S+?)(?:-\d+)?\s+\d+\s+([\d.]+) ns/op`)
+
+// Reads the ns/op of each run of each benchmark in the given file, keyed by "package.Benchmark"
+func readResults(path string) (map[string][]float64, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	results := map[string][]float64{}
+	pkg := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if p, ok := strings.CutPrefix(line, "pkg: "); ok {
+			pkg = strings.TrimSpace(p)
+			continue
+		}
+
+		m := resultRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		ns, err := strconv.ParseFloat(m[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+
+		key := m[1]
+		if pkg != "" {
+			key = pkg + "." + key
+		}
+		results[key] = append(results[key], ns)
+	}
+
+	return results, scanner.Err()
+}
+
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+func main() {
+	threshold := flag.Float64("threshold", 10, "Percentage slowdown above which a benchmark counts as a regression.")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: benchcmp [-threshold percent] old.txt new.txt")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() != 2 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	oldResults, err := readResults(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	newResults, err := readResults(flag.Arg(1))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	var names []string
+	for k := range oldResults {
+		names = append(names, k)
+	}
+	for k := range newResults {
+		if _, ok := oldResults[k]; !ok {
+			names = append(names, k)
+		}
+	}
+	slices.Sort(names)
+
+	regressions := 0
+	w := bufio.NewWriter(os.Stdout)
+	fmt.Fprintf(w, "%-50s %14s %14s %9s\n", "benchmark", "old ns/op", "new ns/op", "delta")
+	for _, name := range names {
+		oldRuns, inOld := oldResults[name]
+		newRuns, inNew := newResults[name]
+		switch {
+		case !inOld:
+			fmt.Fprintf(w, "%-50s %14s %14.1f %9s\n", name, "-", median(newRuns), "new")
+		case !inNew:
+			fmt.Fprintf(w, "%-50s %14.1f %14s %9s\n", name, median(oldRuns), "-", "removed")
+		default:
+			oldNs, newNs := median(oldRuns), median(newRuns)
+			delta := (newNs - oldNs) / oldNs * 100
+
+			flagText := ""
+			if delta > *threshold {
+				flagText = "  REGRESSION"
+				regressions++
+			}
+			fmt.Fprintf(w, "%-50s %14.1f %14.1f %+8.1f%%%s\n", name, oldNs, newNs, delta, flagText)
+		}
+	}
+
+	if regressions > 0 {
+		fmt.Fprintf(w, "\n%d benchmark(s) slower by more than %.1f%%\n", regressions, *threshold)
+	}
+	w.Flush()
+
+	if regressions > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,42 @@
+// Writes a random input for a day, as produced by the gen package.
+//
+// Usage:
+//
+//	go run ./tools/geninput -day 12 -seed 7 -size 50 > inputs/day12_random.txt
+//
+// The same day, seed and size always give the same input.
+package main
+
+import (
+	"aoc/gen"
+	"flag"
+	"fmt"
+	"os"
+)
+
+func main() {
+	day := flag.Int("day", 0, "Day to generate an input for.")
+	seed := flag.Int64("seed", 1, "Seed for the random generator.")
+	size := flag.Int("size", 20, "Rough size of the input; usually the number of lines, or the width and height of a grid.")
+	out := flag.String("o", "", "File to write the input to; defaults to stdout.")
+	flag.Usage = func() {
+		fmt.Fprintln(flag.CommandLine.Output(), "Usage: geninput -day n [-seed n] [-size n] [-o file]")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	input, err := gen.Generate(*day, *seed, *size)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if *out == "" {
+		fmt.Print(input)
+		return
+	}
+	if err := os.WriteFile(*out, []byte(input), 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,52 @@
+package utils
+
+import "math/bits"
+
+// A fixed-size set of small non-negative integers, stored one bit per value.  Much cheaper than a
+// map[int]bool (or map[Point]bool, via Point.ToIndex) when the values are dense.
+type Bitset []uint64
+
+// Creates a bitset which can hold the values [0, size).
+func NewBitset(size int) Bitset {
+	return make(Bitset, (size+63)/64)
+}
+
+func (b Bitset) Set(i int) {
+	b[i/64] |= 1 << (i % 64)
+}
+
+func (b Bitset) Test(i int) bool {
+	return b[i/64]&(1<<(i%64)) != 0
+}
+
+// Sets i, returning whether or not it was already set.
+func (b Bitset) TestAndSet(i int) bool {
+	word, mask := &b[i/64], uint64(1)<<(i%64)
+	was := *word&mask != 0
+	*word |= mask
+	return was
+}
+
+// Removes every value from the set.
+func (b Bitset) Clear() {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// Adds every value in other to b.  The bitsets must be the same size.
+func (b Bitset) Union(other Bitset) {
+	for i := range b {
+		b[i] |= other[i]
+	}
+}
+
+// Gets the number of values in the set.
+func (b Bitset) Count() int {
+	count := 0
+	for _, w := range b {
+		count += bits.OnesCount64(w)
+	}
+
+	return count
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+
+// Returned when a result doesn't fit in an int64
+var ErrOverflow = errors.New("integer overflow")
+
+// Adds two integers, returning false if the result overflows.
+func AddChecked(a, b int64) (int64, bool) {
+	if (b > 0 && a > math.MaxInt64-b) || (b < 0 && a < math.MinInt64-b) {
+		return 0, false
+	}
+
+	return a + b, true
+}
+
+// Subtracts b from a, returning false if the result overflows.
+func SubChecked(a, b int64) (int64, bool) {
+	if (b < 0 && a > math.MaxInt64+b) || (b > 0 && a < math.MinInt64+b) {
+		return 0, false
+	}
+
+	return a - b, true
+}
+
+// Multiplies two integers, returning false if the result overflows.
+func MulChecked(a, b int64) (int64, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+
+	result := a * b
+	// -1 * MinInt64 wraps back around to MinInt64, which the division check can't catch
+	if (a == -1 && b == math.MinInt64) || (b == -1 && a == math.MinInt64) || result/b != a {
+		return 0, false
+	}
+
+	return result, true
+}
+
+// Finds the least common multiple of the given integers, returning ErrOverflow if it doesn't fit
+// in an int64.
+func LCMChecked(a, b int64, integers ...int64) (int64, error) {
+	if a == 0 || b == 0 {
+		return 0, nil
+	}
+
+	result, ok := MulChecked(a/int64(GCD(int(a), int(b))), b)
+	if !ok {
+		return 0, ErrOverflow
+	}
+	if result < 0 {
+		if result == math.MinInt64 {
+			return 0, ErrOverflow
+		}
+		result = -result
+	}
+
+	if len(integers) > 0 {
+		return LCMChecked(result, integers[0], integers[1:]...)
+	}
+
+	return result, nil
+}
+
+// Greatest common divisor of any number of big integers.  The result is always non-negative.
+func BigGCD(a *big.Int, integers ...*big.Int) *big.Int {
+	result := new(big.Int).Abs(a)
+	for _, v := range integers {
+		result.GCD(nil, nil, result, new(big.Int).Abs(v))
+	}
+
+	return result
+}
+
+// Least common multiple of any number of big integers, which can't overflow.  The result is always
+// non-negative.
+func BigLCM(a *big.Int, integers ...*big.Int) *big.Int {
+	result := new(big.Int).Abs(a)
+	for _, v := range integers {
+		if result.Sign() == 0 || v.Sign() == 0 {
+			return new(big.Int)
+		}
+
+		g := new(big.Int).GCD(nil, nil, result, new(big.Int).Abs(v))
+		result.Quo(result, g).Mul(result, new(big.Int).Abs(v))
+	}
+
+	return result
+}
@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestCheckedArithmetic(t *testing.T) {
+	const minInt, maxInt = math.MinInt64, math.MaxInt64
+	tests := []struct {
+		name   string
+		op     func(a, b int64) (int64, bool)
+		a, b   int64
+		want   int64
+		wantOk bool
+	}{
+		{"add", AddChecked, 2, 3, 5, true},
+		{"add", AddChecked, maxInt, 0, maxInt, true},
+		{"add", AddChecked, maxInt, 1, 0, false},
+		{"add", AddChecked, minInt, -1, 0, false},
+		{"add", AddChecked, minInt, 1, minInt + 1, true},
+		{"add", AddChecked, minInt, maxInt, -1, true},
+		{"add", AddChecked, -1, minInt, 0, false},
+
+		{"sub", SubChecked, 2, 3, -1, true},
+		{"sub", SubChecked, minInt, 1, 0, false},
+		{"sub", SubChecked, minInt, -1, minInt + 1, true},
+		{"sub", SubChecked, -1, minInt, maxInt, true},
+		{"sub", SubChecked, 0, minInt, 0, false},
+		{"sub", SubChecked, maxInt, -1, 0, false},
+		{"sub", SubChecked, -1, maxInt, minInt, true},
+
+		{"mul", MulChecked, 6, -7, -42, true},
+		{"mul", MulChecked, minInt, 0, 0, true},
+		{"mul", MulChecked, minInt, 1, minInt, true},
+		{"mul", MulChecked, minInt, -1, 0, false},
+		{"mul", MulChecked, -1, minInt, 0, false},
+		{"mul", MulChecked, -1, maxInt, -maxInt, true},
+		{"mul", MulChecked, 1 << 32, 1 << 31, 0, false},
+		{"mul", MulChecked, 1 << 31, -(1 << 32), minInt, true},
+		{"mul", MulChecked, maxInt, 2, 0, false},
+	}
+
+	for _, test := range tests {
+		got, ok := test.op(test.a, test.b)
+		if ok != test.wantOk || (ok && got != test.want) {
+			t.Errorf("%s(%d, %d) = %d, %t; want %d, %t", test.name, test.a, test.b, got, ok, test.want, test.wantOk)
+		}
+	}
+}
+
+func TestLCMChecked(t *testing.T) {
+	tests := []struct {
+		integers []int64
+		want     int64
+		wantErr  error
+	}{
+		{[]int64{4, 6}, 12, nil},
+		{[]int64{-4, 6}, 12, nil},
+		{[]int64{4, -6}, 12, nil},
+		{[]int64{2, 3, 4, 5}, 60, nil},
+		{[]int64{0, 5}, 0, nil},
+		{[]int64{2, 3, 0}, 0, nil},
+		{[]int64{math.MaxInt64, 1}, math.MaxInt64, nil},
+		{[]int64{math.MinInt64, 1}, 0, ErrOverflow},
+		{[]int64{math.MinInt64, -1}, 0, ErrOverflow},
+		{[]int64{1 << 62, 3}, 0, ErrOverflow},
+		{[]int64{1 << 40, 1 << 41, 1 << 62}, 1 << 62, nil},
+		// Both fit, but their LCM doesn't
+		{[]int64{4294967311, 4294967357}, 0, ErrOverflow},
+	}
+
+	for _, test := range tests {
+		got, err := LCMChecked(test.integers[0], test.integers[1], test.integers[2:]...)
+		if !errors.Is(err, test.wantErr) || got != test.want {
+			t.Errorf("LCMChecked%v = %d, %v; want %d, %v", test.integers, got, err, test.want, test.wantErr)
+		}
+	}
+}
+
+func TestBigLCMAndGCD(t *testing.T) {
+	a, _ := new(big.Int).SetString("4294967311", 10)
+	b, _ := new(big.Int).SetString("-4294967357", 10)
+	want, _ := new(big.Int).SetString("18446744400127067027", 10)
+
+	if got := BigLCM(a, b); got.Cmp(want) != 0 {
+		t.Errorf("BigLCM = %v, want %v", got, want)
+	}
+	if got := BigGCD(big.NewInt(-12), big.NewInt(18), big.NewInt(8)); got.Int64() != 2 {
+		t.Errorf("BigGCD = %v, want 2", got)
+	}
+	if got := BigLCM(big.NewInt(6), big.NewInt(0)); got.Sign() != 0 {
+		t.Errorf("BigLCM with 0 = %v, want 0", got)
+	}
+}
@@ -0,0 +1,132 @@
+// Writes graphs in the Graphviz DOT language, for visualising puzzle networks.
+//
+// Nodes, edges and clusters are written in the order they were added, so output is deterministic
+// as long as the caller adds things in a deterministic order.
+package dot
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Graphviz attributes, such as "color" or "style"
+type Attrs map[string]string
+
+type Node struct {
+	ID    string
+	Attrs Attrs
+}
+
+type Edge struct {
+	From  string
+	To    string
+	Attrs Attrs
+}
+
+// A group of nodes drawn together inside a box.  Graphviz can only draw a node in one cluster, so
+// a node should be added to at most one.
+type Cluster struct {
+	Label string
+	Nodes []string
+	Attrs Attrs
+}
+
+type Graph struct {
+	Name     string
+	Directed bool
+	// Attributes applied to the graph as a whole
+	Attrs    Attrs
+	Nodes    []Node
+	Edges    []Edge
+	Clusters []Cluster
+}
+
+// Creates an empty graph with the given name.
+func NewGraph(name string, directed bool) *Graph {
+	return &Graph{Name: name, Directed: directed, Attrs: Attrs{}}
+}
+
+func (g *Graph) AddNode(id string, attrs Attrs) {
+	g.Nodes = append(g.Nodes, Node{id, attrs})
+}
+
+func (g *Graph) AddEdge(from, to string, attrs Attrs) {
+	g.Edges = append(g.Edges, Edge{from, to, attrs})
+}
+
+func (g *Graph) AddCluster(label string, nodes []string, attrs Attrs) {
+	g.Clusters = append(g.Clusters, Cluster{label, nodes, attrs})
+}
+
+// Quotes an ID or attribute value so any characters are allowed in it
+func quote(s string) string {
+	return "\"" + strings.NewReplacer("\\", "\\\\", "\"", "\\\"", "\n", "\\n").Replace(s) + "\""
+}
+
+// Gets the attribute names in sorted order, so output is deterministic
+func sortedKeys(attrs Attrs) []string {
+	var keys []string
+	for k := range attrs {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
+
+// Formats attributes as " [key=value, ...]", or "" if there are none
+func formatAttrs(attrs Attrs) string {
+	if len(attrs) == 0 {
+		return ""
+	}
+
+	var parts []string
+	for _, k := range sortedKeys(attrs) {
+		parts = append(parts, k+"="+quote(attrs[k]))
+	}
+
+	return " [" + strings.Join(parts, ", ") + "]"
+}
+
+// Writes the graph in DOT format.
+func (g *Graph) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	kind, edgeOp := "graph", "--"
+	if g.Directed {
+		kind, edgeOp = "digraph", "->"
+	}
+
+	fmt.Fprintf(bw, "%s %s {\n", kind, quote(g.Name))
+	for _, k := range sortedKeys(g.Attrs) {
+		fmt.Fprintf(bw, "  %s=%s;\n", k, quote(g.Attrs[k]))
+	}
+
+	for i, c := range g.Clusters {
+		// Graphviz only treats subgraphs as clusters if their name starts with "cluster"
+		fmt.Fprintf(bw, "  subgraph %s {\n", quote(fmt.Sprintf("cluster_%d", i)))
+		fmt.Fprintf(bw, "    label=%s;\n", quote(c.Label))
+		for _, k := range sortedKeys(c.Attrs) {
+			fmt.Fprintf(bw, "    %s=%s;\n", k, quote(c.Attrs[k]))
+		}
+		for _, n := range c.Nodes {
+			fmt.Fprintf(bw, "    %s;\n", quote(n))
+		}
+		fmt.Fprintln(bw, "  }")
+	}
+
+	for _, n := range g.Nodes {
+		fmt.Fprintf(bw, "  %s%s;\n", quote(n.ID), formatAttrs(n.Attrs))
+	}
+
+	for _, e := range g.Edges {
+		fmt.Fprintf(bw, "  %s %s %s%s;\n", quote(e.From), edgeOp, quote(e.To), formatAttrs(e.Attrs))
+	}
+
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
@@ -0,0 +1,103 @@
+package dot
+
+import (
+	"strings"
+	"testing"
+)
+
+// Writes the graph to a string, failing the test on any error
+func writeString(t *testing.T, g *Graph) string {
+	t.Helper()
+
+	var s strings.Builder
+	if err := g.Write(&s); err != nil {
+		t.Fatal(err)
+	}
+
+	return s.String()
+}
+
+func TestWriteDirected(t *testing.T) {
+	g := NewGraph("net", true)
+	g.Attrs["rankdir"] = "LR"
+	g.Attrs["bgcolor"] = "white"
+	g.AddCluster("first", []string{"a", "b"}, Attrs{"color": "gray", "bgcolor": "lightyellow"})
+	g.AddCluster("second", []string{"c"}, nil)
+	g.AddNode("a", Attrs{"style": "filled", "fillcolor": "palegreen", "color": "black"})
+	g.AddNode("b", nil)
+	g.AddNode("c", Attrs{})
+	g.AddEdge("a", "b", Attrs{"label": "L", "color": "blue"})
+	g.AddEdge("b", "c", nil)
+	g.AddEdge("c", "a", Attrs{"style": "dashed"})
+
+	want := `digraph "net" {
+  bgcolor="white";
+  rankdir="LR";
+  subgraph "cluster_0" {
+    label="first";
+    bgcolor="lightyellow";
+    color="gray";
+    "a";
+    "b";
+  }
+  subgraph "cluster_1" {
+    label="second";
+    "c";
+  }
+  "a" [color="black", fillcolor="palegreen", style="filled"];
+  "b";
+  "c";
+  "a" -> "b" [color="blue", label="L"];
+  "b" -> "c";
+  "c" -> "a" [style="dashed"];
+}
+`
+	if got := writeString(t, g); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteUndirected(t *testing.T) {
+	g := NewGraph("wires", false)
+	g.AddNode("x", nil)
+	g.AddNode("y", nil)
+	g.AddEdge("x", "y", Attrs{"weight": "2"})
+
+	want := `graph "wires" {
+  "x";
+  "y";
+  "x" -- "y" [weight="2"];
+}
+`
+	if got := writeString(t, g); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteQuoting(t *testing.T) {
+	g := NewGraph(`say "hi"`, true)
+	g.AddCluster("two\nlines", []string{`back\slash`}, nil)
+	g.AddNode(`back\slash`, Attrs{"label": `a "quoted" label`})
+	g.AddNode("node -> with; syntax", nil)
+	g.AddEdge(`back\slash`, "node -> with; syntax", Attrs{"label": "line 1\nline 2"})
+
+	want := `digraph "say \"hi\"" {
+  subgraph "cluster_0" {
+    label="two\nlines";
+    "back\\slash";
+  }
+  "back\\slash" [label="a \"quoted\" label"];
+  "node -> with; syntax";
+  "back\\slash" -> "node -> with; syntax" [label="line 1\nline 2"];
+}
+`
+	if got := writeString(t, g); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestWriteEmpty(t *testing.T) {
+	if got, want := writeString(t, NewGraph("", false)), "graph \"\" {\n}\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,162 @@
+// Polygon and segment geometry on integer lattice points.
+//
+// Polygons are given as a slice of vertices in order; the edge from the last vertex back to the
+// first is implied.  Repeating the first vertex at the end (as day 18's parsing does) is harmless,
+// since it just adds a zero-length edge.
+//
+// All calculations are done in integer arithmetic.  Where a result can be a half-integer (like the
+// area of a lattice polygon), the doubled value is returned instead.
+package geom
+
+import "aoc/utils"
+
+// The direction of the turn made when travelling through 3 points
+type Orientation int
+
+const (
+	Collinear Orientation = iota
+	// Counter-clockwise in the standard math coordinate system (y up).  In grid coordinates where y
+	// points down (as with utils.UP/utils.DOWN), this appears clockwise on screen.
+	CounterClockwise
+	// Clockwise in the standard math coordinate system (y up).  In grid coordinates where y points
+	// down, this appears counter-clockwise on screen.
+	Clockwise
+)
+
+// Where a point lies relative to a polygon
+type Containment int
+
+const (
+	Outside Containment = iota
+	OnBoundary
+	Inside
+)
+
+// Cross product of the vectors (a - o) and (b - o)
+func cross(o, a, b utils.Point) int {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+// Gets the orientation of the turn made when travelling from a to b to c.
+func GetOrientation(a, b, c utils.Point) Orientation {
+	c1 := cross(a, b, c)
+	switch {
+	case c1 > 0:
+		return CounterClockwise
+	case c1 < 0:
+		return Clockwise
+	default:
+		return Collinear
+	}
+}
+
+// Gets twice the signed area of the polygon via the shoelace formula.  The result is positive if
+// the vertices are counter-clockwise (in y-up coordinates) and negative if they are clockwise.
+func SignedDoubleArea(polygon []utils.Point) int {
+	area := 0
+	for i := range polygon {
+		next := polygon[(i+1)%len(polygon)]
+		area += polygon[i].X*next.Y - next.X*polygon[i].Y
+	}
+
+	return area
+}
+
+// Gets the orientation of the polygon's vertices, based on the sign of its area.
+func PolygonOrientation(polygon []utils.Point) Orientation {
+	area := SignedDoubleArea(polygon)
+	switch {
+	case area > 0:
+		return CounterClockwise
+	case area < 0:
+		return Clockwise
+	default:
+		return Collinear
+	}
+}
+
+// Gets the perimeter of the polygon, measuring each edge by its manhattan length.  For rectilinear
+// polygons (every edge is horizontal or vertical, as with grid paths), this is the exact perimeter.
+func Perimeter(polygon []utils.Point) int {
+	perimeter := 0
+	for i := range polygon {
+		perimeter += utils.ManhattanDistance(polygon[i], polygon[(i+1)%len(polygon)])
+	}
+
+	return perimeter
+}
+
+// Counts the number of lattice points which lie on the boundary of the polygon.  An edge with
+// deltas (dx, dy) passes through exactly GCD(|dx|, |dy|) lattice points, not counting its start.
+func BoundaryPoints(polygon []utils.Point) int {
+	count := 0
+	for i := range polygon {
+		delta := polygon[(i+1)%len(polygon)].Sub(polygon[i])
+		count += utils.GCD(utils.Abs(delta.X), utils.Abs(delta.Y))
+	}
+
+	return count
+}
+
+// Counts the number of lattice points strictly inside the polygon, via Pick's theorem.  The
+// polygon must be simple (no self-intersections).
+func InteriorPoints(polygon []utils.Point) int {
+	// Pick's theorem: A = i + b/2 - 1, so i = (2A - b + 2) / 2
+	return (utils.Abs(SignedDoubleArea(polygon))-BoundaryPoints(polygon))/2 + 1
+}
+
+// Returns whether or not p lies on the closed segment from a to b.
+func OnSegment(p, a, b utils.Point) bool {
+	if cross(a, b, p) != 0 {
+		return false
+	}
+
+	return min(a.X, b.X) <= p.X && p.X <= max(a.X, b.X) && min(a.Y, b.Y) <= p.Y && p.Y <= max(a.Y, b.Y)
+}
+
+// Returns whether or not the closed segments a1-a2 and b1-b2 share at least one point.  Segments
+// which merely touch at an endpoint, or which overlap collinearly, count as intersecting.
+func SegmentsIntersect(a1, a2, b1, b2 utils.Point) bool {
+	o1 := GetOrientation(a1, a2, b1)
+	o2 := GetOrientation(a1, a2, b2)
+	o3 := GetOrientation(b1, b2, a1)
+	o4 := GetOrientation(b1, b2, a2)
+
+	// General case; each segment's endpoints are on opposite sides of the other
+	if o1 != o2 && o3 != o4 && o1 != Collinear && o2 != Collinear && o3 != Collinear && o4 != Collinear {
+		return true
+	}
+
+	// Special cases; an endpoint of one lies on the other
+	return OnSegment(b1, a1, a2) || OnSegment(b2, a1, a2) || OnSegment(a1, b1, b2) || OnSegment(a2, b1, b2)
+}
+
+// Determines whether the point lies inside, outside, or on the boundary of the polygon, using the
+// crossing number (even-odd) rule.
+func PointInPolygon(p utils.Point, polygon []utils.Point) Containment {
+	inside := false
+	for i := range polygon {
+		a := polygon[i]
+		b := polygon[(i+1)%len(polygon)]
+
+		if OnSegment(p, a, b) {
+			return OnBoundary
+		}
+
+		// Count edges crossing the horizontal ray going right from p.  Using a half-open interval on
+		// y means a ray passing exactly through a vertex is only counted once.
+		if (a.Y > p.Y) != (b.Y > p.Y) {
+			// The edge's x coordinate at p.Y is to the right of p.  Compare without division by
+			// checking which side of the edge p falls on.
+			c := cross(a, b, p)
+			if (c > 0) == (b.Y > a.Y) {
+				inside = !inside
+			}
+		}
+	}
+
+	if inside {
+		return Inside
+	}
+	return Outside
+}
@@ -0,0 +1,177 @@
+package geom
+
+import (
+	"aoc/utils"
+	"slices"
+	"testing"
+)
+
+// Gets a polygon from pairs of coordinates
+func polygon(coords ...int) []utils.Point {
+	var points []utils.Point
+	for i := 0; i < len(coords); i += 2 {
+		points = append(points, utils.Point{X: coords[i], Y: coords[i+1]})
+	}
+
+	return points
+}
+
+func TestPolygonMeasurements(t *testing.T) {
+	tests := []struct {
+		name                                   string
+		polygon                                []utils.Point
+		doubleArea, perimeter, boundary, inner int
+		orientation                            Orientation
+	}{
+		{"unit square", polygon(0, 0, 1, 0, 1, 1, 0, 1), 2, 4, 4, 0, CounterClockwise},
+		{"unit square reversed", polygon(0, 1, 1, 1, 1, 0, 0, 0), -2, 4, 4, 0, Clockwise},
+		{"unit square closed", polygon(0, 0, 1, 0, 1, 1, 0, 1, 0, 0), 2, 4, 4, 0, CounterClockwise},
+		{"rectangle", polygon(0, 0, 4, 0, 4, 3, 0, 3), 24, 14, 14, 6, CounterClockwise},
+		{"rectangle reversed", polygon(0, 0, 0, 3, 4, 3, 4, 0), -24, 14, 14, 6, Clockwise},
+		{"offset", polygon(-5, -5, -1, -5, -1, -2, -5, -2), 24, 14, 14, 6, CounterClockwise},
+		{"triangle", polygon(0, 0, 4, 0, 0, 4), 16, 16, 12, 3, CounterClockwise},
+		{"half-integer area", polygon(0, 0, 1, 0, 0, 1), 1, 4, 3, 0, CounterClockwise},
+		{"L shape", polygon(0, 0, 2, 0, 2, 1, 1, 1, 1, 2, 0, 2), 6, 8, 8, 0, CounterClockwise},
+	}
+
+	for _, test := range tests {
+		if got := SignedDoubleArea(test.polygon); got != test.doubleArea {
+			t.Errorf("%s: SignedDoubleArea = %d, want %d", test.name, got, test.doubleArea)
+		}
+		if got := PolygonOrientation(test.polygon); got != test.orientation {
+			t.Errorf("%s: PolygonOrientation = %d, want %d", test.name, got, test.orientation)
+		}
+		if got := Perimeter(test.polygon); got != test.perimeter {
+			t.Errorf("%s: Perimeter = %d, want %d", test.name, got, test.perimeter)
+		}
+		if got := BoundaryPoints(test.polygon); got != test.boundary {
+			t.Errorf("%s: BoundaryPoints = %d, want %d", test.name, got, test.boundary)
+		}
+		if got := InteriorPoints(test.polygon); got != test.inner {
+			t.Errorf("%s: InteriorPoints = %d, want %d", test.name, got, test.inner)
+		}
+
+		// Reversing the vertices flips the sign of the area, but nothing else
+		reversed := slices.Clone(test.polygon)
+		slices.Reverse(reversed)
+		if got := SignedDoubleArea(reversed); got != -test.doubleArea {
+			t.Errorf("%s: reversed SignedDoubleArea = %d, want %d", test.name, got, -test.doubleArea)
+		}
+		if got := InteriorPoints(reversed); got != test.inner {
+			t.Errorf("%s: reversed InteriorPoints = %d, want %d", test.name, got, test.inner)
+		}
+	}
+}
+
+func TestDegeneratePolygons(t *testing.T) {
+	for _, p := range [][]utils.Point{
+		nil,
+		polygon(3, 4),
+		polygon(0, 0, 5, 0),
+		polygon(0, 0, 2, 0, 4, 0),
+		polygon(0, 0, 1, 1, 3, 3, 2, 2),
+	} {
+		if got := SignedDoubleArea(p); got != 0 {
+			t.Errorf("%v: SignedDoubleArea = %d, want 0", p, got)
+		}
+		if got := PolygonOrientation(p); got != Collinear {
+			t.Errorf("%v: PolygonOrientation = %d, want Collinear", p, got)
+		}
+	}
+
+	if got := BoundaryPoints(polygon(0, 0, 2, 0, 4, 0)); got != 8 {
+		t.Errorf("BoundaryPoints of a doubled-back line = %d, want 8", got)
+	}
+}
+
+func TestGetOrientation(t *testing.T) {
+	a, b := utils.Point{X: 0, Y: 0}, utils.Point{X: 2, Y: 0}
+	tests := []struct {
+		c    utils.Point
+		want Orientation
+	}{
+		{utils.Point{X: 3, Y: 1}, CounterClockwise},
+		{utils.Point{X: 3, Y: -1}, Clockwise},
+		{utils.Point{X: 5, Y: 0}, Collinear},
+		{utils.Point{X: -1, Y: 0}, Collinear},
+	}
+
+	for _, test := range tests {
+		if got := GetOrientation(a, b, test.c); got != test.want {
+			t.Errorf("GetOrientation(%v, %v, %v) = %d, want %d", a, b, test.c, got, test.want)
+		}
+	}
+}
+
+func TestSegmentsIntersect(t *testing.T) {
+	tests := []struct {
+		name    string
+		segs    []utils.Point
+		want    bool
+		onFirst bool
+	}{
+		{"crossing", polygon(0, 0, 4, 4, 0, 4, 4, 0), true, false},
+		{"touching at an end", polygon(0, 0, 4, 0, 4, 0, 4, 3), true, true},
+		{"end on the middle", polygon(0, 0, 4, 0, 2, 0, 2, 3), true, true},
+		{"collinear overlap", polygon(0, 0, 4, 0, 3, 0, 6, 0), true, true},
+		{"collinear apart", polygon(0, 0, 2, 0, 3, 0, 6, 0), false, false},
+		{"parallel", polygon(0, 0, 4, 0, 0, 1, 4, 1), false, false},
+		{"would cross if longer", polygon(0, 0, 2, 2, 0, 4, 1, 3), false, false},
+	}
+
+	for _, test := range tests {
+		s := test.segs
+		if got := SegmentsIntersect(s[0], s[1], s[2], s[3]); got != test.want {
+			t.Errorf("%s: SegmentsIntersect = %t, want %t", test.name, got, test.want)
+		}
+		if got := SegmentsIntersect(s[2], s[3], s[0], s[1]); got != test.want {
+			t.Errorf("%s: swapped SegmentsIntersect = %t, want %t", test.name, got, test.want)
+		}
+		if got := OnSegment(s[2], s[0], s[1]); got != test.onFirst {
+			t.Errorf("%s: OnSegment = %t, want %t", test.name, got, test.onFirst)
+		}
+	}
+}
+
+func TestPointInPolygon(t *testing.T) {
+	// A U shape, so rays pass in and out more than once
+	u := polygon(0, 0, 6, 0, 6, 4, 4, 4, 4, 2, 2, 2, 2, 4, 0, 4)
+	tests := []struct {
+		p    utils.Point
+		want Containment
+	}{
+		{utils.Point{X: 1, Y: 1}, Inside},
+		{utils.Point{X: 1, Y: 3}, Inside},
+		{utils.Point{X: 5, Y: 3}, Inside},
+		{utils.Point{X: 3, Y: 3}, Outside},
+		{utils.Point{X: 3, Y: 1}, Inside},
+		{utils.Point{X: -1, Y: 2}, Outside},
+		{utils.Point{X: 7, Y: 1}, Outside},
+		// The ray from here passes through vertices of the U
+		{utils.Point{X: 1, Y: 2}, Inside},
+		{utils.Point{X: -1, Y: 4}, Outside},
+		{utils.Point{X: 0, Y: 0}, OnBoundary},
+		{utils.Point{X: 3, Y: 2}, OnBoundary},
+		{utils.Point{X: 6, Y: 1}, OnBoundary},
+	}
+
+	for _, test := range tests {
+		if got := PointInPolygon(test.p, u); got != test.want {
+			t.Errorf("PointInPolygon(%v) = %d, want %d", test.p, got, test.want)
+		}
+	}
+
+	// Every interior point of a rectangle should be found inside, matching Pick's theorem
+	rect := polygon(0, 0, 4, 0, 4, 3, 0, 3)
+	inside := 0
+	for x := -1; x <= 5; x++ {
+		for y := -1; y <= 4; y++ {
+			if PointInPolygon(utils.Point{X: x, Y: y}, rect) == Inside {
+				inside++
+			}
+		}
+	}
+	if want := InteriorPoints(rect); inside != want {
+		t.Errorf("found %d points inside the rectangle, want %d", inside, want)
+	}
+}
@@ -0,0 +1,32 @@
+package utils
+
+import "testing"
+
+// Keeps results alive so the compiler can't optimise the benchmarked calls away
+var sink int
+
+func BenchmarkGridGet(b *testing.B) {
+	grid := GridFromDimensions[int](140, 140)
+	sum := 0
+	for i := 0; i < b.N; i++ {
+		for y := 0; y < grid.Height(); y++ {
+			for x := 0; x < grid.Width(); x++ {
+				sum += *grid.Get(Point{x, y})
+			}
+		}
+	}
+	sink = sum
+}
+
+func BenchmarkGridGetCopy(b *testing.B) {
+	grid := GridFromDimensions[int](140, 140)
+	sum := 0
+	for i := 0; i < b.N; i++ {
+		for y := 0; y < grid.Height(); y++ {
+			for x := 0; x < grid.Width(); x++ {
+				sum += grid.GetCopy(Point{x, y})
+			}
+		}
+	}
+	sink = sum
+}
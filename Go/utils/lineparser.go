@@ -0,0 +1,314 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// An error parsing a line, at the given (1-based) column of the line
+type ParseError struct {
+	Column int
+	Msg    string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Msg)
+}
+
+// Parses the given string into v.  The string starts at the given (0-based) offset in the line,
+// for error reporting.
+type valueParser func(s string, offset int, v reflect.Value) error
+
+// Parses lines into a struct of type T, as described by the struct's pattern.
+//
+// A struct declares its pattern with a tag on a blank field, naming the fields to fill in braces:
+//
+//	type Game struct {
+//		_      struct{} `pattern:"Game {ID}: {Rounds}"`
+//		ID     int
+//		Rounds [][]Cube `sep:"; " sep2:", "`
+//	}
+//
+// Fields may be ints, uints, strings, []byte, structs with a pattern of their own, or slices of any
+// of these.  Each level of slice needs a separator: the sep tag gives the outermost, then sep2, sep3
+// and so on.  Fields not named in the pattern are left alone.
+//
+// Each field runs up to the first match of the text after it in the pattern, or to the end of the
+// line if there is none, so two fields can't be next to each other.  A space in a pattern or
+// separator matches any run of spaces, and "{{" and "}}" match literal braces.
+type LineParser[T any] struct {
+	parse valueParser
+}
+
+// Creates a parser for the pattern of T, which must be a struct
+func NewLineParser[T any]() (*LineParser[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("can't parse lines into %v; only structs have patterns", t)
+	}
+
+	parse, err := getStructParser(t)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LineParser[T]{parse}, nil
+}
+
+// Creates a parser for the pattern of T, panicking if the pattern is invalid.  Intended for
+// package-level parsers.
+func MustLineParser[T any]() *LineParser[T] {
+	p, err := NewLineParser[T]()
+	CheckError(err)
+	return p
+}
+
+// Parses a line into a new T.  Any error is a *ParseError giving the column where matching failed.
+func (p *LineParser[T]) Parse(line string) (T, error) {
+	var result T
+	err := p.parse(line, 0, reflect.ValueOf(&result).Elem())
+	return result, err
+}
+
+// Matches text from a pattern at position pos of s, returning the position after the match
+func matchText(s string, pos int, text string) (int, bool) {
+	for i := 0; i < len(text); {
+		if text[i] == ' ' {
+			if pos >= len(s) || s[pos] != ' ' {
+				return pos, false
+			}
+			for pos < len(s) && s[pos] == ' ' {
+				pos++
+			}
+			for i < len(text) && text[i] == ' ' {
+				i++
+			}
+			continue
+		}
+
+		if pos >= len(s) || s[pos] != text[i] {
+			return pos, false
+		}
+		pos++
+		i++
+	}
+
+	return pos, true
+}
+
+// Finds the first match of text from a pattern in s at or after from, returning its start and end,
+// or -1, -1 if there is none
+func findText(s string, from int, text string) (int, int) {
+	for i := from; i < len(s); i++ {
+		if end, ok := matchText(s, i, text); ok {
+			return i, end
+		}
+	}
+
+	return -1, -1
+}
+
+// A piece of a struct's pattern: text to match, then the field it leads up to
+type patternPart struct {
+	text  string
+	name  string
+	index int
+	parse valueParser
+}
+
+// Splits a pattern into the fields it names and the text before each; the final part holds the
+// text after the last field, and has no name.
+func splitPattern(pattern string) ([]patternPart, error) {
+	var parts []patternPart
+	var text strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "{{"), strings.HasPrefix(pattern[i:], "}}"):
+			text.WriteByte(pattern[i])
+			i++
+		case pattern[i] == '{':
+			end := strings.IndexByte(pattern[i:], '}')
+			if end == -1 {
+				return nil, fmt.Errorf("unclosed \"{\" at column %d", i+1)
+			}
+			if len(parts) > 0 && text.Len() == 0 {
+				return nil, fmt.Errorf("field at column %d must be separated from the one before it", i+1)
+			}
+
+			parts = append(parts, patternPart{text: text.String(), name: pattern[i+1 : i+end]})
+			text.Reset()
+			i += end
+		case pattern[i] == '}':
+			return nil, fmt.Errorf("unmatched \"}\" at column %d", i+1)
+		default:
+			text.WriteByte(pattern[i])
+		}
+	}
+
+	return append(parts, patternPart{text: text.String()}), nil
+}
+
+// Gets the separators for each level of slice from a field's tags
+func getSeparators(tag reflect.StructTag) []string {
+	var seps []string
+	for key := "sep"; ; key = fmt.Sprintf("sep%d", len(seps)+1) {
+		sep, ok := tag.Lookup(key)
+		if !ok {
+			return seps
+		}
+		seps = append(seps, sep)
+	}
+}
+
+// Gets a parser for a struct, from the pattern on its blank field
+func getStructParser(t reflect.Type) (valueParser, error) {
+	pattern, found := "", false
+	for i := 0; i < t.NumField() && !found; i++ {
+		if f := t.Field(i); f.Name == "_" {
+			pattern, found = f.Tag.Lookup("pattern")
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%v has no pattern", t)
+	}
+
+	parts, err := splitPattern(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("pattern for %v: %w", t, err)
+	}
+
+	last := len(parts) - 1
+	for i := range parts[:last] {
+		f, ok := t.FieldByName(parts[i].name)
+		if !ok || !f.IsExported() || len(f.Index) != 1 {
+			return nil, fmt.Errorf("pattern for %v: no exported field %q", t, parts[i].name)
+		}
+
+		parts[i].index = f.Index[0]
+		parts[i].parse, err = getValueParser(f.Type, getSeparators(f.Tag))
+		if err != nil {
+			return nil, fmt.Errorf("field %s of %v: %w", f.Name, t, err)
+		}
+	}
+
+	return func(s string, offset int, v reflect.Value) error {
+		pos := 0
+		for i, part := range parts {
+			end, ok := matchText(s, pos, part.text)
+			if !ok {
+				return &ParseError{offset + end + 1, fmt.Sprintf("expected %q", part.text)}
+			}
+			pos = end
+
+			if i == last {
+				break
+			}
+
+			// The field runs up to the text following it
+			fieldEnd := len(s)
+			if next := parts[i+1].text; next != "" {
+				if fieldEnd, _ = findText(s, pos, next); fieldEnd == -1 {
+					return &ParseError{offset + len(s) + 1, fmt.Sprintf("expected %q after %s", next, part.name)}
+				}
+			}
+
+			if err := part.parse(s[pos:fieldEnd], offset+pos, v.Field(part.index)); err != nil {
+				return err
+			}
+			pos = fieldEnd
+		}
+
+		if pos != len(s) {
+			return &ParseError{offset + pos + 1, fmt.Sprintf("unexpected %q", s[pos:])}
+		}
+		return nil
+	}, nil
+}
+
+// Gets a parser for a slice, splitting on the first separator and parsing each item with the rest
+func getSliceParser(t reflect.Type, seps []string) (valueParser, error) {
+	if len(seps) == 0 || seps[0] == "" {
+		return nil, errors.New("slices need a non-empty separator")
+	}
+	sep := seps[0]
+
+	parseItem, err := getValueParser(t.Elem(), seps[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	return func(s string, offset int, v reflect.Value) error {
+		if s == "" {
+			v.Set(reflect.Zero(t))
+			return nil
+		}
+
+		items := reflect.MakeSlice(t, 0, 0)
+		pos := 0
+		for {
+			start, end := findText(s, pos, sep)
+			itemEnd := start
+			if start == -1 {
+				itemEnd = len(s)
+			}
+
+			item := reflect.New(t.Elem()).Elem()
+			if err := parseItem(s[pos:itemEnd], offset+pos, item); err != nil {
+				return err
+			}
+			items = reflect.Append(items, item)
+
+			if start == -1 {
+				break
+			}
+			pos = end
+		}
+
+		v.Set(items)
+		return nil
+	}, nil
+}
+
+// Gets a parser for values of the given type
+func getValueParser(t reflect.Type, seps []string) (valueParser, error) {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(s string, offset int, v reflect.Value) error {
+			n, err := strconv.ParseInt(s, 10, t.Bits())
+			if err != nil {
+				return &ParseError{offset + 1, fmt.Sprintf("invalid integer %q", s)}
+			}
+			v.SetInt(n)
+			return nil
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return func(s string, offset int, v reflect.Value) error {
+			n, err := strconv.ParseUint(s, 10, t.Bits())
+			if err != nil {
+				return &ParseError{offset + 1, fmt.Sprintf("invalid unsigned integer %q", s)}
+			}
+			v.SetUint(n)
+			return nil
+		}, nil
+	case reflect.String:
+		return func(s string, offset int, v reflect.Value) error {
+			v.SetString(s)
+			return nil
+		}, nil
+	case reflect.Struct:
+		return getStructParser(t)
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return func(s string, offset int, v reflect.Value) error {
+				v.SetBytes([]byte(s))
+				return nil
+			}, nil
+		}
+		return getSliceParser(t, seps)
+	}
+
+	return nil, fmt.Errorf("unsupported type %v", t)
+}
@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type testCube struct {
+	_     struct{} `pattern:"{Count} {Color}"`
+	Count int
+	Color string
+}
+
+type testGame struct {
+	_      struct{} `pattern:"Game {ID}: {Rounds}"`
+	ID     int
+	Rounds [][]testCube `sep:"; " sep2:", "`
+}
+
+type testCard struct {
+	_        struct{} `pattern:"Card {ID}: {Winning} | {Chosen}"`
+	ID       uint8
+	Winning  []int `sep:" "`
+	Chosen   []int `sep:" "`
+	Unparsed int
+}
+
+type testWorkflow struct {
+	_     struct{} `pattern:"{Name}{{{Rules}}}"`
+	Name  []byte
+	Rules []string `sep:","`
+}
+
+func TestLineParser(t *testing.T) {
+	game, err := MustLineParser[testGame]().Parse("Game 12: 3 blue, 4 red; 1 red, 2 green, 6 blue; 2 green")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantGame := testGame{
+		ID: 12,
+		Rounds: [][]testCube{
+			{{Count: 3, Color: "blue"}, {Count: 4, Color: "red"}},
+			{{Count: 1, Color: "red"}, {Count: 2, Color: "green"}, {Count: 6, Color: "blue"}},
+			{{Count: 2, Color: "green"}},
+		},
+	}
+	if !reflect.DeepEqual(game, wantGame) {
+		t.Errorf("got %+v, want %+v", game, wantGame)
+	}
+
+	card, err := MustLineParser[testCard]().Parse("Card   1: 41 48  3 | 83  6 31")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantCard := testCard{ID: 1, Winning: []int{41, 48, 3}, Chosen: []int{83, 6, 31}}
+	if !reflect.DeepEqual(card, wantCard) {
+		t.Errorf("got %+v, want %+v", card, wantCard)
+	}
+
+	workflow, err := MustLineParser[testWorkflow]().Parse("px{a<2006:qkq,m>2090:A,rfg}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantWorkflow := testWorkflow{Name: []byte("px"), Rules: []string{"a<2006:qkq", "m>2090:A", "rfg"}}
+	if !reflect.DeepEqual(workflow, wantWorkflow) {
+		t.Errorf("got %+v, want %+v", workflow, wantWorkflow)
+	}
+}
+
+func TestLineParserErrorColumns(t *testing.T) {
+	tests := []struct {
+		line   string
+		column int
+	}{
+		{"Gam 1: 1 red", 4},
+		{"Game x: 1 red", 6},
+		{"Game 1 1 red", 13},
+		{"Game 1: 1 red; 2 green, 3", 26},
+		{"Game 1: 1 red, x blue", 16},
+		{"Game 1: 1red", 13},
+	}
+
+	p := MustLineParser[testGame]()
+	for _, test := range tests {
+		_, err := p.Parse(test.line)
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%q: expected a ParseError, got %v", test.line, err)
+		} else if parseErr.Column != test.column {
+			t.Errorf("%q: got error at column %d, want %d (%v)", test.line, parseErr.Column, test.column, err)
+		}
+	}
+
+	if _, err := MustLineParser[testCard]().Parse("Card 300: 1 | 2"); err == nil {
+		t.Error("expected an error for a value out of range")
+	}
+	if _, err := MustLineParser[testWorkflow]().Parse("px{rfg}x"); err == nil {
+		t.Error("expected an error for trailing text")
+	}
+}
+
+// Gets the error from creating a parser for T
+func patternError[T any]() error {
+	_, err := NewLineParser[T]()
+	return err
+}
+
+func TestLineParserBadPatterns(t *testing.T) {
+	type adjacent struct {
+		_    struct{} `pattern:"{A}{B}"`
+		A, B string
+	}
+	type unknownField struct {
+		_ struct{} `pattern:"{A}"`
+	}
+	type unexported struct {
+		_ struct{} `pattern:"{a}"`
+		a int
+	}
+	type noSeparator struct {
+		_ struct{} `pattern:"{A}"`
+		A []int
+	}
+	type unsupported struct {
+		_ struct{} `pattern:"{A}"`
+		A float64
+	}
+	type noPattern struct {
+		A int
+	}
+
+	errs := []error{
+		patternError[adjacent](),
+		patternError[unknownField](),
+		patternError[unexported](),
+		patternError[noSeparator](),
+		patternError[unsupported](),
+		patternError[noPattern](),
+		patternError[int](),
+	}
+
+	for i, err := range errs {
+		if err == nil {
+			t.Errorf("case %d: expected an error for a bad pattern", i)
+		}
+	}
+}
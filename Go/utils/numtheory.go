@@ -0,0 +1,229 @@
+package utils
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+	"slices"
+)
+
+// Gets a mod m, always in the range [0, m) even for negative a.  m must be positive.
+func Mod(a, m int) int {
+	r := a % m
+	if r < 0 {
+		r += m
+	}
+
+	return r
+}
+
+// Extended Euclidean algorithm.  Gets g = GCD(a, b) along with x and y such that a*x + b*y == g.
+// g is always non-negative.
+func ExtendedGCD(a, b int) (g, x, y int) {
+	oldR, r := a, b
+	oldX, x := 1, 0
+	oldY, y := 0, 1
+	for r != 0 {
+		q := oldR / r
+		oldR, r = r, oldR-q*r
+		oldX, x = x, oldX-q*x
+		oldY, y = y, oldY-q*y
+	}
+
+	if oldR < 0 {
+		return -oldR, -oldX, -oldY
+	}
+	return oldR, oldX, oldY
+}
+
+// Gets x in [0, m) such that a*x == 1 (mod m), or false if a and m aren't coprime or m isn't
+// positive.
+func ModInverse(a, m int) (int, bool) {
+	if m <= 0 {
+		return 0, false
+	}
+
+	g, x, _ := ExtendedGCD(Mod(a, m), m)
+	if g != 1 {
+		return 0, false
+	}
+
+	return Mod(x, m), true
+}
+
+// Gets a*b mod m without overflowing, even when a*b doesn't fit in an int.  m must be positive.
+func MulMod(a, b, m int) int {
+	hi, lo := bits.Mul64(uint64(Mod(a, m)), uint64(Mod(b, m)))
+	return int(bits.Rem64(hi, lo, uint64(m)))
+}
+
+// Gets base^exp mod m via repeated squaring.  exp must be non-negative and m positive.
+func ModPow(base, exp, m int) int {
+	if exp < 0 {
+		panic("Negative exponent")
+	}
+
+	result := 1 % m
+	base = Mod(base, m)
+	for exp > 0 {
+		if exp&1 == 1 {
+			result = MulMod(result, base, m)
+		}
+		base = MulMod(base, base, m)
+		exp >>= 1
+	}
+
+	return result
+}
+
+// The set of integers congruent to Remainder modulo Modulus.  These use big integers, since
+// combining congruences multiplies their moduli.  A modulus of 0 means the integer must equal
+// Remainder exactly.
+type Congruence struct {
+	Remainder *big.Int
+	Modulus   *big.Int
+}
+
+// Creates the congruence x == remainder (mod modulus), with the remainder normalised to
+// [0, |modulus|).  A modulus of 0 gives the congruence satisfied only by remainder itself.
+func NewCongruence(remainder, modulus int) Congruence {
+	m := new(big.Int).Abs(big.NewInt(int64(modulus)))
+	if m.Sign() == 0 {
+		return Congruence{big.NewInt(int64(remainder)), m}
+	}
+
+	return Congruence{new(big.Int).Mod(big.NewInt(int64(remainder)), m), m}
+}
+
+// Combines two congruences into the single congruence satisfied by exactly the numbers satisfying
+// both, via the Chinese Remainder Theorem generalised to moduli which aren't coprime.  Returns
+// false if no number satisfies both.
+func CombineCongruences(c1, c2 Congruence) (Congruence, bool) {
+	// A zero modulus allows a single number, which satisfies the other congruence or doesn't
+	if c2.Modulus.Sign() == 0 {
+		c1, c2 = c2, c1
+	}
+	if c1.Modulus.Sign() == 0 {
+		diff := new(big.Int).Sub(c1.Remainder, c2.Remainder)
+		if c2.Modulus.Sign() == 0 {
+			return c1, diff.Sign() == 0
+		}
+		return c1, new(big.Int).Mod(diff, c2.Modulus).Sign() == 0
+	}
+
+	// Solve r1 + m1*k == r2 (mod m2).  With g = GCD(m1, m2), there is a solution only if g divides
+	// (r2 - r1), in which case k == (r2 - r1)/g * inverse(m1/g) (mod m2/g)
+	g := new(big.Int).GCD(nil, nil, c1.Modulus, c2.Modulus)
+	diff := new(big.Int).Sub(c2.Remainder, c1.Remainder)
+	if new(big.Int).Mod(diff, g).Sign() != 0 {
+		return Congruence{}, false
+	}
+
+	m2g := new(big.Int).Quo(c2.Modulus, g)
+	inv := new(big.Int).ModInverse(new(big.Int).Quo(c1.Modulus, g), m2g)
+	if inv == nil {
+		// m1/g and m2/g are coprime, so this only happens when m2/g == 1
+		inv = big.NewInt(0)
+	}
+
+	k := new(big.Int).Quo(diff, g)
+	k.Mul(k, inv).Mod(k, m2g)
+
+	lcm := new(big.Int).Mul(c1.Modulus, m2g)
+	rem := new(big.Int).Mul(c1.Modulus, k)
+	rem.Add(rem, c1.Remainder).Mod(rem, lcm)
+
+	return Congruence{rem, lcm}, true
+}
+
+// Finds the congruence satisfied by exactly the numbers satisfying all of the given ones (the
+// Chinese Remainder Theorem).  The moduli needn't be coprime.  Returns false if the congruences
+// are incompatible.  With no congruences, every number is a solution (x == 0 mod 1).
+func CRT(congruences ...Congruence) (Congruence, bool) {
+	result := Congruence{big.NewInt(0), big.NewInt(1)}
+	for _, c := range congruences {
+		var ok bool
+		result, ok = CombineCongruences(result, c)
+		if !ok {
+			return Congruence{}, false
+		}
+	}
+
+	return result, true
+}
+
+// A prime factor and the number of times it divides a number
+type PrimePower struct {
+	Prime    int
+	Exponent int
+}
+
+// Gets the prime factorisation of n (which must be positive) in increasing order of prime, via
+// trial division.  This is quick for anything up to around 10^14.
+func Factorize(n int) []PrimePower {
+	if n < 1 {
+		panic("Can only factorize positive numbers")
+	}
+
+	var result []PrimePower
+	divideOut := func(p int) {
+		count := 0
+		for n%p == 0 {
+			n /= p
+			count++
+		}
+		if count > 0 {
+			result = append(result, PrimePower{p, count})
+		}
+	}
+
+	divideOut(2)
+	divideOut(3)
+	// Every other prime is 1 more or less than a multiple of 6
+	for p := 5; p <= n/p; p += 6 {
+		divideOut(p)
+		divideOut(p + 2)
+	}
+	if n > 1 {
+		result = append(result, PrimePower{n, 1})
+	}
+
+	return result
+}
+
+// Gets every positive divisor of n (which must be positive) in increasing order.
+func Divisors(n int) []int {
+	divisors := []int{1}
+	for _, pp := range Factorize(n) {
+		count := len(divisors)
+		power := 1
+		for e := 0; e < pp.Exponent; e++ {
+			power *= pp.Prime
+			for _, d := range divisors[:count] {
+				divisors = append(divisors, d*power)
+			}
+		}
+	}
+
+	slices.Sort(divisors)
+	return divisors
+}
+
+// Gets the integer square root of n; that is, the largest x such that x*x <= n.  n must be
+// non-negative.
+func Isqrt(n int) int {
+	if n < 0 {
+		panic("Square root of negative number")
+	}
+
+	// The float estimate is only off by a little for large n, so correct it
+	x := int(math.Sqrt(float64(n)))
+	for x > 0 && x > n/x {
+		x--
+	}
+	for x+1 <= n/(x+1) {
+		x++
+	}
+
+	return x
+}
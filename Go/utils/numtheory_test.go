@@ -0,0 +1,189 @@
+package utils
+
+import (
+	"math"
+	"math/big"
+	"slices"
+	"testing"
+)
+
+func TestExtendedGCD(t *testing.T) {
+	for _, pair := range [][2]int{{240, 46}, {-240, 46}, {240, -46}, {17, 5}, {0, 7}, {7, 0}, {0, 0}, {math.MaxInt, 2}} {
+		a, b := pair[0], pair[1]
+		g, x, y := ExtendedGCD(a, b)
+		if want := Abs(GCD(a, b)); g != want || a*x+b*y != g {
+			t.Errorf("ExtendedGCD(%d, %d) = %d, %d, %d; want gcd %d with %d*x + %d*y == gcd", a, b, g, x, y, want, a, b)
+		}
+	}
+}
+
+func TestModInverse(t *testing.T) {
+	tests := []struct {
+		a, m   int
+		want   int
+		wantOk bool
+	}{
+		{3, 7, 5, true},
+		{-3, 7, 2, true},
+		{10, 7, 5, true},
+		{1, 1, 0, true},
+		{4, 8, 0, false},
+		{6, 9, 0, false},
+		{0, 5, 0, false},
+		{3, 0, 0, false},
+		{3, -7, 0, false},
+	}
+
+	for _, test := range tests {
+		got, ok := ModInverse(test.a, test.m)
+		if got != test.want || ok != test.wantOk {
+			t.Errorf("ModInverse(%d, %d) = %d, %t; want %d, %t", test.a, test.m, got, ok, test.want, test.wantOk)
+		}
+	}
+}
+
+func TestMulModAndModPow(t *testing.T) {
+	toBig := func(v int) *big.Int { return big.NewInt(int64(v)) }
+	values := []int{0, 1, -1, 2, 12345, -987654321, math.MaxInt - 1, math.MaxInt, math.MinInt}
+	moduli := []int{1, 2, 1000000007, math.MaxInt - 24, math.MaxInt}
+
+	for _, m := range moduli {
+		for _, a := range values {
+			for _, b := range values {
+				want := new(big.Int).Mul(toBig(a), toBig(b))
+				want.Mod(want, toBig(m))
+				if got := MulMod(a, b, m); int64(got) != want.Int64() {
+					t.Errorf("MulMod(%d, %d, %d) = %d, want %v", a, b, m, got, want)
+				}
+			}
+
+			for _, exp := range []int{0, 1, 2, 63, 1000000006, math.MaxInt} {
+				base := new(big.Int).Mod(toBig(a), toBig(m))
+				want := new(big.Int).Exp(base, toBig(exp), toBig(m))
+				if got := ModPow(a, exp, m); int64(got) != want.Int64() {
+					t.Errorf("ModPow(%d, %d, %d) = %d, want %v", a, exp, m, got, want)
+				}
+			}
+		}
+	}
+}
+
+func TestCRT(t *testing.T) {
+	tests := []struct {
+		name        string
+		congruences []Congruence
+		want        Congruence
+		wantOk      bool
+	}{
+		{"none", nil, NewCongruence(0, 1), true},
+		{"coprime", []Congruence{NewCongruence(2, 3), NewCongruence(3, 5), NewCongruence(2, 7)}, NewCongruence(23, 105), true},
+		{"negative remainder", []Congruence{NewCongruence(-1, 4), NewCongruence(1, 9)}, NewCongruence(19, 36), true},
+		{"not coprime", []Congruence{NewCongruence(2, 4), NewCongruence(4, 6)}, NewCongruence(10, 12), true},
+		{"one divides the other", []Congruence{NewCongruence(5, 12), NewCongruence(1, 4)}, NewCongruence(5, 12), true},
+		{"same modulus", []Congruence{NewCongruence(3, 8), NewCongruence(11, 8)}, NewCongruence(3, 8), true},
+		{"incompatible", []Congruence{NewCongruence(1, 4), NewCongruence(2, 6)}, Congruence{}, false},
+		{"incompatible same modulus", []Congruence{NewCongruence(1, 8), NewCongruence(2, 8)}, Congruence{}, false},
+		{"incompatible later", []Congruence{NewCongruence(1, 3), NewCongruence(2, 5), NewCongruence(0, 15)}, Congruence{}, false},
+		{"zero modulus", []Congruence{NewCongruence(2, 5), NewCongruence(17, 0)}, NewCongruence(17, 0), true},
+		{"zero modulus first", []Congruence{NewCongruence(17, 0), NewCongruence(2, 5)}, NewCongruence(17, 0), true},
+		{"zero modulus negative", []Congruence{NewCongruence(-3, 0), NewCongruence(2, 5)}, NewCongruence(-3, 0), true},
+		{"zero modulus incompatible", []Congruence{NewCongruence(2, 5), NewCongruence(16, 0)}, Congruence{}, false},
+		{"two zero moduli", []Congruence{NewCongruence(4, 0), NewCongruence(4, 0)}, NewCongruence(4, 0), true},
+		{"two different zero moduli", []Congruence{NewCongruence(4, 0), NewCongruence(5, 0)}, Congruence{}, false},
+	}
+
+	for _, test := range tests {
+		got, ok := CRT(test.congruences...)
+		if ok != test.wantOk {
+			t.Errorf("%s: got ok %t, want %t", test.name, ok, test.wantOk)
+		} else if ok && (got.Remainder.Cmp(test.want.Remainder) != 0 || got.Modulus.Cmp(test.want.Modulus) != 0) {
+			t.Errorf("%s: got %v mod %v, want %v mod %v", test.name, got.Remainder, got.Modulus, test.want.Remainder, test.want.Modulus)
+		}
+	}
+}
+
+func TestCRTBeyondInt64(t *testing.T) {
+	primes := []int{1000000007, 998244353, 1000000009, 4294967311}
+	var congruences []Congruence
+	for i, p := range primes {
+		congruences = append(congruences, NewCongruence(i+1, p))
+	}
+
+	got, ok := CRT(congruences...)
+	if !ok {
+		t.Fatal("expected coprime congruences to be compatible")
+	}
+	if got.Modulus.IsInt64() {
+		t.Errorf("expected the modulus %v to overflow an int64", got.Modulus)
+	}
+	for i, p := range primes {
+		if r := new(big.Int).Mod(got.Remainder, big.NewInt(int64(p))); r.Int64() != int64(i+1) {
+			t.Errorf("%v mod %d = %v, want %d", got.Remainder, p, r, i+1)
+		}
+	}
+}
+
+func TestFactorize(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []PrimePower
+	}{
+		{1, nil},
+		{2, []PrimePower{{2, 1}}},
+		{3, []PrimePower{{3, 1}}},
+		{5, []PrimePower{{5, 1}}},
+		{7, []PrimePower{{7, 1}}},
+		{1000000007, []PrimePower{{1000000007, 1}}},
+		{999999999989, []PrimePower{{999999999989, 1}}},
+		{25, []PrimePower{{5, 2}}},
+		{49, []PrimePower{{7, 2}}},
+		{360, []PrimePower{{2, 3}, {3, 2}, {5, 1}}},
+		{2 * 999999999989, []PrimePower{{2, 1}, {999999999989, 1}}},
+		{1 << 62, []PrimePower{{2, 62}}},
+	}
+
+	for _, test := range tests {
+		if got := Factorize(test.n); !slices.Equal(got, test.want) {
+			t.Errorf("Factorize(%d) = %v, want %v", test.n, got, test.want)
+		}
+	}
+}
+
+func TestDivisors(t *testing.T) {
+	tests := []struct {
+		n    int
+		want []int
+	}{
+		{1, []int{1}},
+		{13, []int{1, 13}},
+		{12, []int{1, 2, 3, 4, 6, 12}},
+		{36, []int{1, 2, 3, 4, 6, 9, 12, 18, 36}},
+		{64, []int{1, 2, 4, 8, 16, 32, 64}},
+	}
+
+	for _, test := range tests {
+		if got := Divisors(test.n); !slices.Equal(got, test.want) {
+			t.Errorf("Divisors(%d) = %v, want %v", test.n, got, test.want)
+		}
+	}
+}
+
+func TestIsqrt(t *testing.T) {
+	// The largest root whose square fits in an int
+	const maxRoot = 3037000499
+
+	for _, root := range []int{1, 2, 3, 10, 1 << 20, 99999999, 1<<31 - 1, 1 << 31, 94906265, maxRoot - 1, maxRoot} {
+		square := root * root
+		for _, test := range [][2]int{{square - 1, root - 1}, {square, root}, {square + 1, root}} {
+			if got := Isqrt(test[0]); got != test[1] {
+				t.Errorf("Isqrt(%d) = %d, want %d", test[0], got, test[1])
+			}
+		}
+	}
+
+	for _, test := range [][2]int{{0, 0}, {math.MaxInt, maxRoot}, {math.MaxInt - 1, maxRoot}} {
+		if got := Isqrt(test[0]); got != test[1] {
+			t.Errorf("Isqrt(%d) = %d, want %d", test[0], got, test[1])
+		}
+	}
+}
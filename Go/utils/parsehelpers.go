@@ -36,42 +36,38 @@ func ScanDelimiterFunc(separator string) func(data []byte, atEOF bool) (advance
 }
 
 // ScanDoubleNewLines is a split function for a Scanner that returns a series of strings
-// which were separated by a new-line (either \r\n\r\n or \n\n) in the input.
-// The last non-empty lines of input will be returned even if it has no
-// newline termination.
+// which were separated by a blank line in the input.  Either line ending (\r\n or \n) is
+// accepted, even mixed within one separator.  The last non-empty lines of input will be returned
+// even if they have no newline termination.
 func ScanDoubleNewLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
 	if atEOF && len(data) == 0 {
 		return 0, nil, nil
 	}
 
-	curData := data[0:]
-	idx := 0
-	for len(curData) > 0 {
-		// Find first new-line
-		if i := bytes.IndexByte(curData, '\n'); i >= 0 {
-			// Nothing comes after it so this isn't a terminator
-			if i+1 >= len(curData) {
-				idx += i + 1
-				curData = curData[i+1:]
-				continue
-			}
+	for start := 0; ; {
+		// Find the next new-line
+		i := bytes.IndexByte(data[start:], '\n')
+		if i < 0 {
+			break
+		}
+		i += start
 
-			// Found a group
-			if curData[i+1] == '\n' {
-				return idx + i + 2, dropCR(data[0 : idx+i]), nil
-			} else if i+2 >= len(curData) || i == 0 { // Need \r\n as the sep but not enough data
-				break
-			} else if curData[i-1] == '\r' && curData[i+1] == '\r' && curData[i+2] == '\n' { // \r\n\r\n
-				return idx + i + 3, dropCR(data[0 : idx+i]), nil
-			} else { // Not a separator, only a single new-line
-				idx += i + 1
-				curData = curData[i+1:]
-				continue
-			}
-		} else {
+		// It's a separator if the next line is blank, ie. just another new-line (maybe after a \r)
+		next := i + 1
+		if next < len(data) && data[next] == '\r' {
+			next++
+		}
+		if next >= len(data) {
+			// Not enough data to tell
 			break
 		}
+		if data[next] == '\n' {
+			return next + 1, dropCR(data[0:i]), nil
+		}
+
+		start = i + 1
 	}
+
 	// If we're at EOF, we have a final, non-terminated line. Return it.
 	if atEOF {
 		return len(data), dropCR(data), nil
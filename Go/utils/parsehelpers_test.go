@@ -0,0 +1,270 @@
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// Builds a square grid input of the given size, like most days' inputs
+func makeGridInput(size int) []byte {
+	var b bytes.Buffer
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			b.WriteByte(".#O|-"[(x*7+y*13)%5])
+		}
+		b.WriteByte('\n')
+	}
+
+	return b.Bytes()
+}
+
+// Builds an input of blank-line separated groups, like days 5, 13 and 19
+func makeGroupsInput(groups, linesPerGroup int, sep string) []byte {
+	var b strings.Builder
+	for g := 0; g < groups; g++ {
+		if g > 0 {
+			b.WriteString(sep)
+		}
+		for l := 0; l < linesPerGroup; l++ {
+			b.WriteString("#..##..##.#..#.#" + sep[:len(sep)/2])
+		}
+	}
+
+	return []byte(b.String())
+}
+
+func BenchmarkReadGridFromBytes(b *testing.B) {
+	data := makeGridInput(140)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		if _, err := ReadGridFromBytes(bytes.NewReader(data), func(c byte, p Point) (byte, error) { return c, nil }); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func benchmarkScanDoubleNewLines(b *testing.B, sep string) {
+	data := makeGroupsInput(100, 15, sep)
+	b.SetBytes(int64(len(data)))
+	for i := 0; i < b.N; i++ {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		scanner.Split(ScanDoubleNewLines)
+		for scanner.Scan() {
+		}
+		if err := scanner.Err(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkScanDoubleNewLines(b *testing.B) {
+	benchmarkScanDoubleNewLines(b, "\n\n")
+}
+
+func BenchmarkScanDoubleNewLinesCRLF(b *testing.B) {
+	benchmarkScanDoubleNewLines(b, "\r\n\r\n")
+}
+
+// Scans all the tokens from data.  The scanner is fed one byte at a time, so split functions see
+// every possible partial chunk of the input.
+func scanAll(t *testing.T, data string, split bufio.SplitFunc) []string {
+	scanner := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(data)))
+	scanner.Split(split)
+
+	var tokens []string
+	for scanner.Scan() {
+		tokens = append(tokens, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		t.Fatalf("scanning %q: %v", data, err)
+	}
+
+	return tokens
+}
+
+// Straightforward reference for ScanDelimiterFunc: a separator at the very end of the input doesn't
+// start another token, and empty input has no tokens.
+func referenceSplit(data, sep string) []string {
+	if data == "" {
+		return nil
+	}
+
+	tokens := strings.Split(data, sep)
+	if tokens[len(tokens)-1] == "" {
+		tokens = tokens[:len(tokens)-1]
+	}
+
+	return tokens
+}
+
+// Straightforward reference for ScanDoubleNewLines: a blank line separates groups, and any line
+// may end in \r\n instead of \n.  Tokens come back with \n line endings, and the last one has a
+// trailing \r dropped, as ScanDoubleNewLines does.
+func referenceGroups(data string) []string {
+	if data == "" {
+		return nil
+	}
+
+	groups := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n\n")
+	if groups[len(groups)-1] == "" {
+		groups = groups[:len(groups)-1]
+	} else {
+		groups[len(groups)-1] = strings.TrimSuffix(groups[len(groups)-1], "\r")
+	}
+
+	return groups
+}
+
+// Converts the line endings in each token to \n
+func normalizeTokens(tokens []string) []string {
+	var result []string
+	for _, t := range tokens {
+		result = append(result, strings.ReplaceAll(t, "\r\n", "\n"))
+	}
+
+	return result
+}
+
+func FuzzScanDelimiterFunc(f *testing.F) {
+	for _, seed := range [][2]string{
+		{"", ","},
+		{"a,b,c", ","},
+		{"a,b,", ","},
+		{",,", ","},
+		{"a, b, c", ", "},
+		{"aaa", "aa"},
+		{"Game 1: 3 blue", ": "},
+	} {
+		f.Add(seed[0], seed[1])
+	}
+
+	f.Fuzz(func(t *testing.T, data, sep string) {
+		if sep == "" {
+			t.Skip()
+		}
+
+		tokens := scanAll(t, data, ScanDelimiterFunc(sep))
+		if expected := referenceSplit(data, sep); !slices.Equal(tokens, expected) {
+			t.Fatalf("splitting %q on %q: got %q, expected %q", data, sep, tokens, expected)
+		}
+
+		if joined := strings.Join(tokens, sep); joined != data && joined+sep != data {
+			t.Fatalf("splitting %q on %q: tokens %q don't join back up to the input", data, sep, tokens)
+		}
+	})
+}
+
+func FuzzScanDoubleNewLines(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"\n",
+		"\n\n",
+		"\n\n\n",
+		"\r\n\r\n\r\n",
+		"a\n\nb",
+		"a\r\n\r\nb",
+		"a\n\nb\n\n",
+		"a\r\n\r\nb\r\n\r\n",
+		"a\nb\n\nc\nd\n",
+		"a\n\n\nb",
+		"\na\n\nb",
+		"a\r\n\nb",
+		"a\n\r\nb",
+		"a\r",
+		string(makeGroupsInput(3, 2, "\r\n\r\n")),
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data string) {
+		tokens := scanAll(t, data, ScanDoubleNewLines)
+		if expected := referenceGroups(data); !slices.Equal(normalizeTokens(tokens), expected) {
+			t.Fatalf("splitting %q: got %q, expected %q", data, tokens, expected)
+		}
+
+		if strings.Contains(data, "\r") {
+			return
+		}
+
+		if joined := strings.Join(tokens, "\n\n"); joined != data && joined+"\n\n" != data {
+			t.Fatalf("splitting %q: tokens %q don't join back up to the input", data, tokens)
+		}
+
+		crlfTokens := scanAll(t, strings.ReplaceAll(data, "\n", "\r\n"), ScanDoubleNewLines)
+		if !slices.Equal(normalizeTokens(crlfTokens), tokens) {
+			t.Fatalf("splitting %q: got %q with \\n line endings but %q with \\r\\n", data, tokens, crlfTokens)
+		}
+	})
+}
+
+// Straightforward reference for ReadGroups: every blank line ends a group (even an empty one),
+// and a trailing \n or \r\n doesn't start another line.
+func referenceReadGroups(data string) [][]string {
+	if data == "" {
+		return nil
+	}
+
+	lines := strings.Split(strings.TrimSuffix(data, "\n"), "\n")
+
+	var groups [][]string
+	var cur []string
+	for _, line := range lines {
+		line = strings.TrimSuffix(line, "\r")
+		if line == "" {
+			groups = append(groups, cur)
+			cur = nil
+		} else {
+			cur = append(cur, line)
+		}
+	}
+	if cur != nil {
+		groups = append(groups, cur)
+	}
+
+	return groups
+}
+
+func readGroups(t *testing.T, data string) [][]string {
+	groups, err := ReadGroups(iotest.OneByteReader(strings.NewReader(data)), func(s string) (string, error) { return s, nil })
+	if err != nil {
+		t.Fatalf("reading groups from %q: %v", data, err)
+	}
+
+	return groups
+}
+
+func FuzzReadGroups(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"\n",
+		"\n\n",
+		"a\nb\n\nc",
+		"a\r\nb\r\n\r\nc\r\n",
+		"a\n\n\nb",
+		"a\n\r\nb",
+		"\na",
+		"a\r",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, data string) {
+		groups := readGroups(t, data)
+		expected := referenceReadGroups(data)
+		if !slices.EqualFunc(groups, expected, slices.Equal[[]string]) {
+			t.Fatalf("reading groups from %q: got %q, expected %q", data, groups, expected)
+		}
+
+		if strings.Contains(data, "\r") {
+			return
+		}
+
+		if crlfGroups := readGroups(t, strings.ReplaceAll(data, "\n", "\r\n")); !slices.EqualFunc(crlfGroups, groups, slices.Equal[[]string]) {
+			t.Fatalf("reading groups from %q: got %q with \\n line endings but %q with \\r\\n", data, groups, crlfGroups)
+		}
+	})
+}
@@ -0,0 +1,160 @@
+// Polynomials with exact rational coefficients, for fitting and extrapolating integer sequences.
+package poly
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// A polynomial in one variable.  Coeffs[i] is the coefficient of x^i; there are never trailing
+// zero coefficients, so the zero polynomial has no coefficients at all.
+type Polynomial struct {
+	Coeffs []*big.Rat
+}
+
+// Removes trailing zero coefficients
+func (p *Polynomial) trim() {
+	for len(p.Coeffs) > 0 && p.Coeffs[len(p.Coeffs)-1].Sign() == 0 {
+		p.Coeffs = p.Coeffs[:len(p.Coeffs)-1]
+	}
+}
+
+// Gets the degree of the polynomial.  The zero polynomial has degree -1.
+func (p Polynomial) Degree() int {
+	return len(p.Coeffs) - 1
+}
+
+// Finds the polynomial of minimal degree which passes through every point of the sequence; that
+// is, p(i) == seq[i] for each index i.  The result has degree at most len(seq) - 1.
+//
+// This uses Newton's forward difference formula, which is the same idea as repeatedly taking
+// difference sequences until they become zero:
+//
+//	p(x) = sum over k of (kth difference at 0) * C(x, k)
+//
+// where C(x, k) = x(x-1)...(x-k+1) / k! is expanded into ordinary powers of x.
+func Fit(seq []int) Polynomial {
+	// Leading entry of each successive difference sequence
+	var leading []*big.Int
+	diffs := make([]*big.Int, len(seq))
+	for i, v := range seq {
+		diffs[i] = big.NewInt(int64(v))
+	}
+	for len(diffs) > 0 {
+		leading = append(leading, diffs[0])
+		next := make([]*big.Int, len(diffs)-1)
+		for i := range next {
+			next[i] = new(big.Int).Sub(diffs[i+1], diffs[i])
+		}
+		diffs = next
+	}
+
+	result := Polynomial{}
+
+	// Coefficients of the falling factorial x(x-1)...(x-k+1), built up one factor at a time
+	falling := []*big.Int{big.NewInt(1)}
+	factorial := big.NewInt(1)
+	for k, d := range leading {
+		if k > 0 {
+			// Multiply by (x - (k-1))
+			next := make([]*big.Int, len(falling)+1)
+			for i := range next {
+				next[i] = new(big.Int)
+			}
+			shift := big.NewInt(int64(k - 1))
+			for i, c := range falling {
+				next[i+1].Add(next[i+1], c)
+				next[i].Sub(next[i], new(big.Int).Mul(c, shift))
+			}
+			falling = next
+			factorial.Mul(factorial, big.NewInt(int64(k)))
+		}
+
+		if d.Sign() == 0 {
+			continue
+		}
+
+		// Add d * falling / k!
+		for len(result.Coeffs) < len(falling) {
+			result.Coeffs = append(result.Coeffs, new(big.Rat))
+		}
+		for i, c := range falling {
+			term := new(big.Rat).SetFrac(new(big.Int).Mul(d, c), factorial)
+			result.Coeffs[i].Add(result.Coeffs[i], term)
+		}
+	}
+
+	result.trim()
+	return result
+}
+
+// Evaluates the polynomial at x.  x may be anywhere, including negative or far past the end of the
+// sequence the polynomial was fit to.
+func (p Polynomial) Eval(x int) *big.Rat {
+	result := new(big.Rat)
+	bx := new(big.Rat).SetInt64(int64(x))
+	for i := len(p.Coeffs) - 1; i >= 0; i-- {
+		result.Mul(result, bx)
+		result.Add(result, p.Coeffs[i])
+	}
+
+	return result
+}
+
+// Evaluates the polynomial at x, returning an error if the result is not an integer or doesn't
+// fit in an int.
+func (p Polynomial) EvalInt(x int) (int, error) {
+	v := p.Eval(x)
+	if !v.IsInt() {
+		return 0, fmt.Errorf("p(%d) = %s is not an integer", x, v.RatString())
+	}
+	if !v.Num().IsInt64() {
+		return 0, fmt.Errorf("p(%d) = %s overflows", x, v.RatString())
+	}
+
+	return int(v.Num().Int64()), nil
+}
+
+// Formats the polynomial with the highest power first; eg. "1/2x^2 + 3/2x + 1"
+func (p Polynomial) String() string {
+	if len(p.Coeffs) == 0 {
+		return "0"
+	}
+
+	var s strings.Builder
+	for i := len(p.Coeffs) - 1; i >= 0; i-- {
+		c := p.Coeffs[i]
+		if c.Sign() == 0 {
+			continue
+		}
+
+		if s.Len() > 0 {
+			if c.Sign() < 0 {
+				s.WriteString(" - ")
+			} else {
+				s.WriteString(" + ")
+			}
+			c = new(big.Rat).Abs(c)
+		}
+
+		// Leave off coefficients of 1 on powers of x
+		if i == 0 || c.Cmp(big.NewRat(1, 1)) != 0 {
+			if i > 0 && c.Cmp(big.NewRat(-1, 1)) == 0 {
+				s.WriteString("-")
+			} else {
+				s.WriteString(c.RatString())
+			}
+		}
+
+		switch i {
+		case 0:
+		case 1:
+			s.WriteString("x")
+		default:
+			fmt.Fprintf(&s, "x^%d", i)
+		}
+	}
+
+	return s.String()
+}
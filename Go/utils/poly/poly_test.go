@@ -0,0 +1,117 @@
+package poly
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestFitSample(t *testing.T) {
+	tests := []struct {
+		seq    []int
+		degree int
+		str    string
+		next   int
+		prev   int
+	}{
+		{[]int{0, 3, 6, 9, 12, 15}, 1, "3x", 18, -3},
+		{[]int{1, 3, 6, 10, 15, 21}, 2, "1/2x^2 + 3/2x + 1", 28, 0},
+		{[]int{10, 13, 16, 21, 30, 45}, 3, "1/3x^3 - x^2 + 11/3x + 10", 68, 5},
+	}
+
+	for _, test := range tests {
+		p := Fit(test.seq)
+		if got := p.Degree(); got != test.degree {
+			t.Errorf("%v: got degree %d, want %d", test.seq, got, test.degree)
+		}
+		if got := p.String(); got != test.str {
+			t.Errorf("%v: got %q, want %q", test.seq, got, test.str)
+		}
+
+		// The polynomial passes through every point it was fit to
+		for i, v := range test.seq {
+			if got, err := p.EvalInt(i); err != nil || got != v {
+				t.Errorf("%v: p(%d) = %d, %v; want %d", test.seq, i, got, err, v)
+			}
+		}
+
+		if got, err := p.EvalInt(len(test.seq)); err != nil || got != test.next {
+			t.Errorf("%v: next value is %d, %v; want %d", test.seq, got, err, test.next)
+		}
+		if got, err := p.EvalInt(-1); err != nil || got != test.prev {
+			t.Errorf("%v: previous value is %d, %v; want %d", test.seq, got, err, test.prev)
+		}
+	}
+}
+
+func TestFitShapes(t *testing.T) {
+	tests := []struct {
+		seq    []int
+		degree int
+		str    string
+	}{
+		{nil, -1, "0"},
+		{[]int{0, 0, 0}, -1, "0"},
+		{[]int{5, 5, 5}, 0, "5"},
+		{[]int{-7}, 0, "-7"},
+		{[]int{0, 1, 2, 3}, 1, "x"},
+		{[]int{0, -1, -2}, 1, "-x"},
+		{[]int{1, 0, -3}, 2, "-x^2 + 1"},
+		{[]int{0, -1, 4, 21, 56}, 3, "x^3 - 2x"},
+	}
+
+	for _, test := range tests {
+		p := Fit(test.seq)
+		if got := p.Degree(); got != test.degree {
+			t.Errorf("%v: got degree %d, want %d", test.seq, got, test.degree)
+		}
+		if got := p.String(); got != test.str {
+			t.Errorf("%v: got %q, want %q", test.seq, got, test.str)
+		}
+	}
+}
+
+func TestEval(t *testing.T) {
+	p := Fit([]int{1, 3, 6, 10, 15, 21})
+
+	if got, want := p.Eval(1000000), big.NewRat(500001500001, 1); got.Cmp(want) != 0 {
+		t.Errorf("p(1000000) = %s, want %s", got.RatString(), want.RatString())
+	}
+
+	// Eval works beyond int, even where EvalInt can't
+	x := 1 << 40
+	want := new(big.Rat).SetInt(new(big.Int).Mul(big.NewInt(int64(x+1)), big.NewInt(int64(x+2))))
+	want.Quo(want, big.NewRat(2, 1))
+	if got := p.Eval(x); got.Cmp(want) != 0 {
+		t.Errorf("p(%d) = %s, want %s", x, got.RatString(), want.RatString())
+	}
+	if _, err := p.EvalInt(x); err == nil {
+		t.Errorf("EvalInt(%d): expected an overflow error", x)
+	}
+
+	if got := (Polynomial{}).Eval(12); got.Sign() != 0 {
+		t.Errorf("zero polynomial gave %s", got.RatString())
+	}
+}
+
+func TestEvalIntErrors(t *testing.T) {
+	// x^2 overflows well before x does
+	square := Fit([]int{0, 1, 4})
+	if got, err := square.EvalInt(math.MaxInt32); err != nil || got != math.MaxInt32*math.MaxInt32 {
+		t.Errorf("p(MaxInt32) = %d, %v; want %d", got, err, math.MaxInt32*math.MaxInt32)
+	}
+	for _, x := range []int{1 << 32, -(1 << 32), math.MaxInt, math.MinInt} {
+		if got, err := square.EvalInt(x); err == nil {
+			t.Errorf("p(%d) = %d; expected an overflow error", x, got)
+		}
+	}
+
+	// A polynomial fit to integers is always an integer at integers, but others needn't be
+	half := Polynomial{Coeffs: []*big.Rat{big.NewRat(0, 1), big.NewRat(1, 2)}}
+	if got, err := half.EvalInt(3); err == nil {
+		t.Errorf("p(3) = %d; expected an error for 3/2", got)
+	}
+	if got, err := half.EvalInt(4); err != nil || got != 2 {
+		t.Errorf("p(4) = %d, %v; want 2", got, err)
+	}
+}
@@ -0,0 +1,178 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+)
+
+// An exact fraction of arbitrarily large integers.  This is a thin wrapper around big.Rat, which
+// keeps values in lowest terms with a positive denominator; the difference is that Rationals are
+// immutable, with arithmetic always returning a new Rational, so they can be copied and compared
+// like plain values.  The zero value is 0.
+type Rational struct {
+	r *big.Rat
+}
+
+// Creates the rational num/den.  Panics if den is 0.
+func NewRational(num, den int64) Rational {
+	return NewRationalBig(big.NewInt(num), big.NewInt(den))
+}
+
+// Creates the rational num/den from big integers, which are copied.  Panics if den is 0.
+func NewRationalBig(num, den *big.Int) Rational {
+	if den.Sign() == 0 {
+		panic("Zero denominator")
+	}
+
+	return Rational{new(big.Rat).SetFrac(num, den)}
+}
+
+// Creates the rational equal to the given integer.
+func RationalFromInt(v int) Rational {
+	return Rational{new(big.Rat).SetInt64(int64(v))}
+}
+
+// Creates a rational from a big.Rat, which is copied.
+func RationalFromRat(r *big.Rat) Rational {
+	return Rational{new(big.Rat).Set(r)}
+}
+
+// Gets the value as a big.Rat, treating the zero value as 0.  The result must not be modified.
+func (r Rational) rat() *big.Rat {
+	if r.r == nil {
+		return new(big.Rat)
+	}
+
+	return r.r
+}
+
+// Gets a copy of the value as a big.Rat.
+func (r Rational) Rat() *big.Rat {
+	return new(big.Rat).Set(r.rat())
+}
+
+// Gets a copy of the numerator (which carries the sign).
+func (r Rational) Num() *big.Int {
+	return new(big.Int).Set(r.rat().Num())
+}
+
+// Gets a copy of the denominator, which is always positive.
+func (r Rational) Den() *big.Int {
+	return new(big.Int).Set(r.rat().Denom())
+}
+
+func (r1 Rational) Add(r2 Rational) Rational {
+	return Rational{new(big.Rat).Add(r1.rat(), r2.rat())}
+}
+
+func (r1 Rational) Sub(r2 Rational) Rational {
+	return Rational{new(big.Rat).Sub(r1.rat(), r2.rat())}
+}
+
+func (r1 Rational) Mul(r2 Rational) Rational {
+	return Rational{new(big.Rat).Mul(r1.rat(), r2.rat())}
+}
+
+// Divides r1 by r2.  Panics if r2 is 0.
+func (r1 Rational) Div(r2 Rational) Rational {
+	if r2.Sign() == 0 {
+		panic("Division by zero")
+	}
+
+	return Rational{new(big.Rat).Quo(r1.rat(), r2.rat())}
+}
+
+func (r Rational) Neg() Rational {
+	return Rational{new(big.Rat).Neg(r.rat())}
+}
+
+// Gets -1, 0 or 1 depending on whether r is negative, zero or positive.
+func (r Rational) Sign() int {
+	return r.rat().Sign()
+}
+
+// Compares r1 and r2, returning -1 if r1 < r2, 0 if they're equal and 1 if r1 > r2.
+func (r1 Rational) Cmp(r2 Rational) int {
+	return r1.rat().Cmp(r2.rat())
+}
+
+func (r1 Rational) Equals(r2 Rational) bool {
+	return r1.Cmp(r2) == 0
+}
+
+// Returns whether or not the rational is a whole number.
+func (r Rational) IsInt() bool {
+	return r.rat().IsInt()
+}
+
+// Gets the rational as an int64.  Returns false if it isn't a whole number or doesn't fit.
+func (r Rational) Int64() (int64, bool) {
+	if !r.IsInt() || !r.rat().Num().IsInt64() {
+		return 0, false
+	}
+
+	return r.rat().Num().Int64(), true
+}
+
+// Formats the rational as "num/den", or just "num" for whole numbers.
+func (r Rational) String() string {
+	return r.rat().RatString()
+}
+
+// Returned when a linear system has no unique solution
+var ErrSingular = errors.New("system has no unique solution")
+
+// Solves the linear system a * x = b exactly via Gauss-Jordan elimination, where a is a square
+// matrix given as a slice of rows.  Returns ErrSingular if there isn't exactly one solution.
+func SolveLinearSystem(a [][]Rational, b []Rational) ([]Rational, error) {
+	n := len(a)
+	if len(b) != n {
+		return nil, errors.New("matrix and vector sizes differ")
+	}
+
+	// Augmented matrix, so we don't modify the caller's slices
+	m := make([][]Rational, n)
+	for i, row := range a {
+		if len(row) != n {
+			return nil, errors.New("matrix is not square")
+		}
+		m[i] = append(append([]Rational{}, row...), b[i])
+	}
+
+	for col := 0; col < n; col++ {
+		pivot := -1
+		for row := col; row < n; row++ {
+			if m[row][col].Sign() != 0 {
+				pivot = row
+				break
+			}
+		}
+		if pivot == -1 {
+			return nil, ErrSingular
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+
+		p := m[col][col]
+		for k := col; k <= n; k++ {
+			m[col][k] = m[col][k].Div(p)
+		}
+
+		for row := 0; row < n; row++ {
+			if row == col || m[row][col].Sign() == 0 {
+				continue
+			}
+
+			factor := m[row][col]
+			for k := col; k <= n; k++ {
+				m[row][k] = m[row][k].Sub(factor.Mul(m[col][k]))
+			}
+		}
+	}
+
+	x := make([]Rational, n)
+	for i := range m {
+		x[i] = m[i][n]
+	}
+
+	return x, nil
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestRational(t *testing.T) {
+	half := NewRational(2, 4)
+	third := NewRational(-1, -3)
+	if half.String() != "1/2" || third.String() != "1/3" {
+		t.Errorf("expected lowest terms, got %v and %v", half, third)
+	}
+	if r := NewRational(3, -6); r.Num().Int64() != -1 || r.Den().Int64() != 2 {
+		t.Errorf("expected the sign on the numerator, got %v/%v", r.Num(), r.Den())
+	}
+
+	var zero Rational
+	if zero.Sign() != 0 || !zero.Equals(NewRational(0, 5)) || zero.String() != "0" || !zero.Add(half).Equals(half) {
+		t.Error("expected the zero value to act as 0")
+	}
+
+	tests := []struct {
+		name string
+		got  Rational
+		want string
+	}{
+		{"add", half.Add(third), "5/6"},
+		{"sub", third.Sub(half), "-1/6"},
+		{"mul", half.Mul(third), "1/6"},
+		{"div", half.Div(third), "3/2"},
+		{"neg", half.Neg(), "-1/2"},
+		{"whole", half.Add(half), "1"},
+	}
+	for _, test := range tests {
+		if test.got.String() != test.want {
+			t.Errorf("%s: got %v, want %s", test.name, test.got, test.want)
+		}
+	}
+	if half.String() != "1/2" || third.String() != "1/3" {
+		t.Error("arithmetic modified its operands")
+	}
+
+	if half.Cmp(third) != 1 || third.Cmp(half) != -1 || half.Cmp(NewRational(5, 10)) != 0 {
+		t.Error("comparisons are wrong")
+	}
+
+	if v, ok := NewRational(-84, 2).Int64(); !ok || v != -42 {
+		t.Errorf("Int64 = %d, %t; want -42, true", v, ok)
+	}
+	if _, ok := half.Int64(); ok {
+		t.Error("expected 1/2 not to be an int64")
+	}
+	huge := NewRationalBig(new(big.Int).Lsh(big.NewInt(1), 64), big.NewInt(1))
+	if _, ok := huge.Int64(); ok || !huge.IsInt() {
+		t.Error("expected 2^64 to be whole but not fit in an int64")
+	}
+
+	r := big.NewRat(1, 2)
+	fromRat := RationalFromRat(r)
+	r.SetInt64(7)
+	if !fromRat.Equals(half) {
+		t.Error("RationalFromRat didn't copy its argument")
+	}
+}
+
+func TestRationalDivByZero(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected division by zero to panic")
+		}
+	}()
+	RationalFromInt(1).Div(Rational{})
+}
+
+// Gets a matrix of rationals from one of ints
+func ratMatrix(rows ...[]int) [][]Rational {
+	m := make([][]Rational, len(rows))
+	for i, row := range rows {
+		for _, v := range row {
+			m[i] = append(m[i], RationalFromInt(v))
+		}
+	}
+
+	return m
+}
+
+func TestSolveLinearSystem(t *testing.T) {
+	// Needs a row swap, since the first pivot is 0
+	a := ratMatrix([]int{0, 2, 1}, []int{1, 1, 1}, []int{2, 0, -1})
+	b := ratMatrix([]int{1, 2, 3})[0]
+	x, err := SolveLinearSystem(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Rational{NewRational(3, 2), NewRational(1, 2), NewRational(0, 1)}
+	for i := range want {
+		if !x[i].Equals(want[i]) {
+			t.Errorf("x[%d] = %v, want %v", i, x[i], want[i])
+		}
+	}
+	if !a[0][0].Equals(Rational{}) || !b[0].Equals(RationalFromInt(1)) {
+		t.Error("SolveLinearSystem modified its arguments")
+	}
+
+	singular := ratMatrix([]int{1, 2}, []int{2, 4})
+	if _, err := SolveLinearSystem(singular, ratMatrix([]int{1, 2})[0]); !errors.Is(err, ErrSingular) {
+		t.Errorf("expected ErrSingular, got %v", err)
+	}
+	if _, err := SolveLinearSystem(ratMatrix([]int{1, 2}), ratMatrix([]int{1})[0]); err == nil {
+		t.Error("expected an error for a non-square matrix")
+	}
+	if _, err := SolveLinearSystem(ratMatrix([]int{1}), nil); err == nil {
+		t.Error("expected an error for mismatched sizes")
+	}
+}
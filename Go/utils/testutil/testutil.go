@@ -0,0 +1,45 @@
+// Helpers shared by each day's tests and benchmarks.
+package testutil
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// Gets the path to the given day's real input, relative to that day's package directory (where
+// go test runs).  Real inputs aren't committed, so the test or benchmark is skipped if it's missing.
+func RealInput(tb testing.TB, day int) string {
+	tb.Helper()
+
+	path := fmt.Sprintf("../inputs/day%02d.txt", day)
+	if _, err := os.Stat(path); err != nil {
+		tb.Skipf("input %s not available", path)
+	}
+
+	return path
+}
+
+// Benchmarks parsing the given day's real input and solving each part of it, as the sub-benchmarks
+// Parse, PartA and PartB.  parse should run the day's parser, discarding everything but the error.
+func BenchmarkDay[A, B any](b *testing.B, day int, parse func(string) error, partA func(string) A, partB func(string) B) {
+	path := RealInput(b, day)
+
+	b.Run("Parse", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if err := parse(path); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("PartA", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			partA(path)
+		}
+	})
+	b.Run("PartB", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			partB(path)
+		}
+	})
+}
@@ -142,9 +142,11 @@ func GCD(a, b int) int {
 	return a
 }
 
-// Find Least Common Multiple (LCM) via GCD
+// Find Least Common Multiple (LCM) via GCD.  This silently overflows for large results; use
+// LCMChecked or BigLCM where that's a possibility.
 func LCM(a, b int, integers ...int) int {
-	result := a * b / GCD(a, b)
+	// Divide first so we only overflow if the result itself doesn't fit
+	result := a / GCD(a, b) * b
 
 	for i := 0; i < len(integers); i++ {
 		result = LCM(result, integers[i])
@@ -0,0 +1,54 @@
+package utils
+
+import "testing"
+
+// Ranges of assorted sizes and positions, some overlapping and some not
+func makeRanges(n int) []Range {
+	ranges := make([]Range, n)
+	for i := range ranges {
+		ranges[i] = NewRange(i*37%1000, i*13%100+1)
+	}
+
+	return ranges
+}
+
+func BenchmarkRangeOverlaps(b *testing.B) {
+	ranges := makeRanges(64)
+	count := 0
+	for i := 0; i < b.N; i++ {
+		for _, r1 := range ranges {
+			for _, r2 := range ranges {
+				if r1.Overlaps(r2) {
+					count++
+				}
+			}
+		}
+	}
+	sink = count
+}
+
+func BenchmarkRangeContainsNum(b *testing.B) {
+	ranges := makeRanges(64)
+	count := 0
+	for i := 0; i < b.N; i++ {
+		for _, r := range ranges {
+			for n := 0; n < 1000; n += 7 {
+				if r.ContainsNum(n) {
+					count++
+				}
+			}
+		}
+	}
+	sink = count
+}
+
+func BenchmarkRangeLength(b *testing.B) {
+	ranges := makeRanges(64)
+	total := 0
+	for i := 0; i < b.N; i++ {
+		for _, r := range ranges {
+			total += r.Length()
+		}
+	}
+	sink = total
+}
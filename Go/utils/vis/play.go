@@ -0,0 +1,130 @@
+package vis
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Options for playing a recording in the terminal
+type PlayOptions struct {
+	// Frames per second to start at
+	FPS int
+	// Whether to start paused
+	Paused bool
+}
+
+// Fastest and slowest speeds the player can be set to, in frames per second
+const (
+	MinFPS = 1
+	MaxFPS = 1000
+)
+
+// ANSI escape codes to switch to each style
+var styleCodes = map[Style]string{
+	Plain: "\x1b[0m",
+	// Bold yellow
+	Highlight: "\x1b[0;1;33m",
+	// Bold white on red
+	Active: "\x1b[0;1;97;41m",
+	// Bold black on green
+	Marked: "\x1b[0;1;30;42m",
+}
+
+// Help shown under the grid
+const playKeys = "space: pause/resume  n: step  +/-: speed  q: quit"
+
+// Draws the initial grid, then the recording's frames one at a time at the given speed.  Each
+// frame only redraws the cells it changes, using ANSI cursor movement.  Keypresses received on
+// keys control playback (see playKeys); keys may be nil if there's no way to read them.  Returns
+// once every frame has been drawn, or the player is quit.
+func Play(rec *Recording, out io.Writer, keys <-chan byte, opts PlayOptions) error {
+	w := bufio.NewWriter(out)
+	height := rec.Initial.Height()
+
+	// Hide the cursor and clear the screen
+	fmt.Fprint(w, "\x1b[?25l\x1b[2J\x1b[H")
+	for y := 0; y < height; y++ {
+		row := rec.Initial.Slice[y*rec.Initial.Width() : (y+1)*rec.Initial.Width()]
+		fmt.Fprintf(w, "%s\r\n", row)
+	}
+
+	fps := min(max(opts.FPS, MinFPS), MaxFPS)
+	paused := opts.Paused
+	next := 0
+
+	drawStatus := func() {
+		state := "playing"
+		if paused {
+			state = "paused"
+		}
+
+		label := ""
+		if next > 0 {
+			label = rec.Frames[next-1].Label
+		}
+
+		// Move below the grid and clear each line before writing it
+		fmt.Fprintf(w, "\x1b[%d;1H\x1b[2K%s\x1b[%d;1H\x1b[2Kframe %d/%d, %d fps, %s  (%s)", height+2, label, height+3, next, len(rec.Frames), fps, state, playKeys)
+	}
+
+	drawFrame := func() {
+		for _, c := range rec.Frames[next].Cells {
+			// Cursor positions are 1-based
+			fmt.Fprintf(w, "\x1b[%d;%dH%s%c", c.Pos.Y+1, c.Pos.X+1, styleCodes[c.Style], c.Char)
+		}
+		fmt.Fprint(w, styleCodes[Plain])
+		next++
+	}
+
+	drawStatus()
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(time.Second / time.Duration(fps))
+	defer timer.Stop()
+
+loop:
+	for next < len(rec.Frames) {
+		select {
+		case k, ok := <-keys:
+			if !ok {
+				keys = nil
+				continue
+			}
+
+			switch k {
+			case ' ':
+				paused = !paused
+			case 'n', '.':
+				if paused {
+					drawFrame()
+				}
+			case '+', '=':
+				fps = min(fps*2, MaxFPS)
+			case '-', '_':
+				fps = max(fps/2, MinFPS)
+			case 'q', 3: // 3 is ctrl-c
+				break loop
+			}
+
+		case <-timer.C:
+			if !paused {
+				drawFrame()
+			}
+			timer.Reset(time.Second / time.Duration(fps))
+		}
+
+		drawStatus()
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+
+	// Leave the cursor below everything we drew, and show it again
+	drawStatus()
+	fmt.Fprintf(w, "\x1b[%d;1H\x1b[?25h", height+4)
+	return w.Flush()
+}
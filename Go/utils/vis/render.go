@@ -0,0 +1,261 @@
+package vis
+
+import (
+	"aoc/utils"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Options for rendering a recording to images
+type RenderOptions struct {
+	// Width and height of each grid cell, in pixels
+	CellSize int
+	// Colour of each character, for Plain cells
+	Palette map[byte]color.RGBA
+	// Colour of Plain cells whose character isn't in Palette
+	DefaultColor color.RGBA
+	// Colour of cells in each style other than Plain, whatever their character
+	StyleColors map[Style]color.RGBA
+	// Time each GIF frame is shown for, in hundredths of a second
+	Delay int
+}
+
+// Gets options which suit most puzzles: walls and rocks stand out from empty space, and styles
+// are coloured like they are in the terminal.
+func DefaultRenderOptions() RenderOptions {
+	return RenderOptions{
+		CellSize: 4,
+		Palette: map[byte]color.RGBA{
+			'.': {0x10, 0x10, 0x18, 0xff},
+			'#': {0x60, 0x60, 0x70, 0xff},
+			'O': {0xd0, 0xd0, 0xd0, 0xff},
+		},
+		DefaultColor: color.RGBA{0x40, 0x40, 0x58, 0xff},
+		StyleColors: map[Style]color.RGBA{
+			Highlight: {0xff, 0xd0, 0x20, 0xff},
+			Active:    {0xe0, 0x30, 0x30, 0xff},
+			Marked:    {0x30, 0xc0, 0x50, 0xff},
+		},
+		Delay: 4,
+	}
+}
+
+// Parses a palette given as comma-separated char=RRGGBB pairs; eg. "#=606070,O=d0d0d0".
+func ParsePalette(spec string) (map[byte]color.RGBA, error) {
+	palette := map[byte]color.RGBA{}
+	for _, entry := range strings.Split(spec, ",") {
+		char, hex, ok := strings.Cut(entry, "=")
+		if !ok || len(char) != 1 || len(hex) != 6 {
+			return nil, fmt.Errorf("palette entry %q: expected char=RRGGBB", entry)
+		}
+
+		rgb, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("palette entry %q: %w", entry, err)
+		}
+		palette[char[0]] = color.RGBA{uint8(rgb >> 16), uint8(rgb >> 8), uint8(rgb), 0xff}
+	}
+
+	return palette, nil
+}
+
+// Draws a recording frame by frame, keeping track of the state of the grid.
+type renderer struct {
+	opts    RenderOptions
+	palette color.Palette
+	// Index within palette of each colour
+	indices map[color.RGBA]uint8
+	chars   utils.Grid[byte]
+	styles  utils.Grid[Style]
+}
+
+func newRenderer(rec *Recording, opts RenderOptions) (*renderer, error) {
+	if opts.CellSize < 1 {
+		return nil, errors.New("cell size must be at least 1")
+	}
+
+	r := &renderer{
+		opts:    opts,
+		indices: map[color.RGBA]uint8{},
+		chars:   utils.GridFromSlice(append([]byte{}, rec.Initial.Slice...), rec.Initial.Width()),
+		styles:  utils.GridFromDimensions[Style](rec.Initial.Width(), rec.Initial.Height()),
+	}
+
+	// Build the palette in a fixed order, so output is the same every run
+	var chars []byte
+	for c := range opts.Palette {
+		chars = append(chars, c)
+	}
+	slices.Sort(chars)
+
+	colors := []color.RGBA{opts.DefaultColor}
+	for _, c := range chars {
+		colors = append(colors, opts.Palette[c])
+	}
+	for _, s := range []Style{Highlight, Active, Marked} {
+		colors = append(colors, opts.StyleColors[s])
+	}
+
+	for _, c := range colors {
+		if _, ok := r.indices[c]; ok {
+			continue
+		}
+		if len(r.palette) == 256 {
+			return nil, errors.New("too many colours for a GIF palette")
+		}
+
+		r.indices[c] = uint8(len(r.palette))
+		r.palette = append(r.palette, c)
+	}
+
+	return r, nil
+}
+
+// Gets the palette index to draw the given cell in
+func (r *renderer) colorIndex(p utils.Point) uint8 {
+	if s := r.styles.GetCopy(p); s != Plain {
+		return r.indices[r.opts.StyleColors[s]]
+	}
+
+	c, ok := r.opts.Palette[r.chars.GetCopy(p)]
+	if !ok {
+		c = r.opts.DefaultColor
+	}
+
+	return r.indices[c]
+}
+
+// Applies a frame to the grid, and returns the bounds of the cells it changed (which is empty if
+// it changed nothing).
+func (r *renderer) apply(f Frame) image.Rectangle {
+	var bounds image.Rectangle
+	for _, c := range f.Cells {
+		r.chars.Set(c.Pos, c.Char)
+		r.styles.Set(c.Pos, c.Style)
+		bounds = bounds.Union(image.Rect(c.Pos.X, c.Pos.Y, c.Pos.X+1, c.Pos.Y+1))
+	}
+
+	return bounds
+}
+
+// Draws the cells within the given bounds (in cells) to a new image, positioned in pixels.
+func (r *renderer) draw(cells image.Rectangle) *image.Paletted {
+	size := r.opts.CellSize
+	img := image.NewPaletted(image.Rect(cells.Min.X*size, cells.Min.Y*size, cells.Max.X*size, cells.Max.Y*size), r.palette)
+	for y := cells.Min.Y; y < cells.Max.Y; y++ {
+		for x := cells.Min.X; x < cells.Max.X; x++ {
+			idx := r.colorIndex(utils.Point{X: x, Y: y})
+			for py := y * size; py < (y+1)*size; py++ {
+				row := img.Pix[img.PixOffset(x*size, py):]
+				for px := 0; px < size; px++ {
+					row[px] = idx
+				}
+			}
+		}
+	}
+
+	return img
+}
+
+// Gets the bounds of the whole grid, in cells
+func (r *renderer) gridBounds() image.Rectangle {
+	return image.Rect(0, 0, r.chars.Width(), r.chars.Height())
+}
+
+// Writes the recording as an animated GIF.  The first frame is the initial grid; after that, each
+// GIF frame only covers the cells its frame changed, which keeps the file small.
+func WriteGIF(w io.Writer, rec *Recording, opts RenderOptions) error {
+	r, err := newRenderer(rec, opts)
+	if err != nil {
+		return err
+	}
+
+	anim := &gif.GIF{}
+	add := func(img *image.Paletted) {
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, opts.Delay)
+		anim.Disposal = append(anim.Disposal, gif.DisposalNone)
+	}
+
+	add(r.draw(r.gridBounds()))
+	for _, f := range rec.Frames {
+		bounds := r.apply(f)
+		if bounds.Empty() {
+			// GIF frames can't be empty, so just hold the last one for longer
+			anim.Delay[len(anim.Delay)-1] += opts.Delay
+			continue
+		}
+		add(r.draw(bounds))
+	}
+
+	return gif.EncodeAll(w, anim)
+}
+
+// Writes each frame of the recording (starting with the initial grid) as a separate PNG.  The
+// files are named after pattern with the frame number added; eg. "out.png" gives "out_0000.png",
+// "out_0001.png" and so on.
+func WritePNGFrames(pattern string, rec *Recording, opts RenderOptions) error {
+	r, err := newRenderer(rec, opts)
+	if err != nil {
+		return err
+	}
+
+	ext := filepath.Ext(pattern)
+	base := strings.TrimSuffix(pattern, ext)
+	write := func(i int) error {
+		f, err := os.Create(fmt.Sprintf("%s_%04d%s", base, i, ext))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if err := png.Encode(f, r.draw(r.gridBounds())); err != nil {
+			return err
+		}
+		return f.Close()
+	}
+
+	if err := write(0); err != nil {
+		return err
+	}
+	for i, f := range rec.Frames {
+		r.apply(f)
+		if err := write(i + 1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Renders the recording to the given file: an animated GIF for ".gif", or numbered PNG frames for
+// ".png".
+func RenderToFile(path string, rec *Recording, opts RenderOptions) error {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gif":
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		if err := WriteGIF(f, rec, opts); err != nil {
+			return err
+		}
+		return f.Close()
+	case ".png":
+		return WritePNGFrames(path, rec, opts)
+	default:
+		return fmt.Errorf("can't render to %q: expected a .gif or .png file", path)
+	}
+}
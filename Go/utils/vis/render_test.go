@@ -0,0 +1,187 @@
+package vis
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestParsePalette(t *testing.T) {
+	got, err := ParsePalette("#=606070,O=d0d0d0,.=000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[byte]color.RGBA{
+		'#': {0x60, 0x60, 0x70, 0xff},
+		'O': {0xd0, 0xd0, 0xd0, 0xff},
+		'.': {0, 0, 0, 0xff},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	for _, spec := range []string{"", "#606070", "=606070", "##=606070", "#=60607", "#=6060700", "#=zzzzzz", "#=+60607", "#=606070,", "#=606070,,O=d0d0d0"} {
+		if p, err := ParsePalette(spec); err == nil {
+			t.Errorf("%q: expected an error, got %v", spec, p)
+		}
+	}
+}
+
+// Gets options with a distinct colour for everything the test recording draws
+func testRenderOptions() RenderOptions {
+	opts := DefaultRenderOptions()
+	opts.CellSize = 2
+	opts.Delay = 3
+	opts.Palette = map[byte]color.RGBA{'.': {0, 0, 0, 0xff}}
+	return opts
+}
+
+// Checks the colour of the pixel at the given position
+func checkPixel(t *testing.T, img image.Image, x, y int, want color.RGBA) {
+	t.Helper()
+
+	if got := color.RGBAModel.Convert(img.At(x, y)); got != want {
+		t.Errorf("pixel (%d, %d) is %v, want %v", x, y, got, want)
+	}
+}
+
+func TestWriteGIF(t *testing.T) {
+	opts := testRenderOptions()
+	var buf bytes.Buffer
+	if err := WriteGIF(&buf, testRecording(), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The empty frame has no image of its own, so the one before it is shown for longer
+	if got, want := anim.Delay, []int{3, 6, 3}; !slices.Equal(got, want) {
+		t.Errorf("got delays %v, want %v", got, want)
+	}
+
+	// The first image covers the whole grid, and the rest just the cells that changed
+	wantBounds := []image.Rectangle{image.Rect(0, 0, 6, 4), image.Rect(2, 0, 4, 2), image.Rect(0, 2, 6, 4)}
+	if len(anim.Image) != len(wantBounds) {
+		t.Fatalf("got %d images, want %d", len(anim.Image), len(wantBounds))
+	}
+	for i, img := range anim.Image {
+		if img.Bounds() != wantBounds[i] {
+			t.Errorf("image %d has bounds %v, want %v", i, img.Bounds(), wantBounds[i])
+		}
+	}
+
+	checkPixel(t, anim.Image[0], 0, 0, opts.DefaultColor)
+	checkPixel(t, anim.Image[0], 5, 1, opts.Palette['.'])
+	checkPixel(t, anim.Image[1], 3, 1, opts.StyleColors[Active])
+	checkPixel(t, anim.Image[2], 0, 2, opts.StyleColors[Marked])
+	checkPixel(t, anim.Image[2], 3, 3, opts.Palette['.'])
+	checkPixel(t, anim.Image[2], 5, 3, opts.StyleColors[Highlight])
+}
+
+func TestWriteGIFEmptyFrames(t *testing.T) {
+	rec := testRecording()
+	rec.Frames = append([]Frame{{}, {}}, rec.Frames...)
+	rec.Frames = append(rec.Frames, Frame{})
+
+	var buf bytes.Buffer
+	if err := WriteGIF(&buf, rec, testRenderOptions()); err != nil {
+		t.Fatal(err)
+	}
+
+	anim, err := gif.DecodeAll(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := anim.Delay, []int{9, 6, 6}; !slices.Equal(got, want) {
+		t.Errorf("got delays %v, want %v", got, want)
+	}
+}
+
+func TestWriteGIFBadOptions(t *testing.T) {
+	opts := testRenderOptions()
+	opts.CellSize = 0
+	if err := WriteGIF(&bytes.Buffer{}, testRecording(), opts); err == nil {
+		t.Error("expected an error for a cell size of 0")
+	}
+
+	// Each character needs its own colour, and there's only room for 256
+	opts = testRenderOptions()
+	for i := 0; i < 256; i++ {
+		opts.Palette[byte(i)] = color.RGBA{byte(i), 1, 2, 0xff}
+	}
+	if err := WriteGIF(&bytes.Buffer{}, testRecording(), opts); err == nil {
+		t.Error("expected an error for too many colours")
+	}
+}
+
+func TestWritePNGFrames(t *testing.T) {
+	dir := t.TempDir()
+	opts := testRenderOptions()
+	if err := WritePNGFrames(filepath.Join(dir, "out.png"), testRecording(), opts); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every frame gets a file, even the empty one
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	if want := []string{"out_0000.png", "out_0001.png", "out_0002.png", "out_0003.png"}; !slices.Equal(names, want) {
+		t.Errorf("got files %v, want %v", names, want)
+	}
+
+	// Each file is the whole grid as of that frame
+	f, err := os.Open(filepath.Join(dir, "out_0003.png"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	img, err := png.Decode(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds() != image.Rect(0, 0, 6, 4) {
+		t.Errorf("got bounds %v, want the whole grid", img.Bounds())
+	}
+	checkPixel(t, img, 0, 0, opts.DefaultColor)
+	checkPixel(t, img, 2, 0, opts.StyleColors[Active])
+	checkPixel(t, img, 1, 3, opts.StyleColors[Marked])
+	checkPixel(t, img, 4, 2, opts.StyleColors[Highlight])
+}
+
+func TestRenderToFile(t *testing.T) {
+	dir := t.TempDir()
+
+	if err := RenderToFile(filepath.Join(dir, "anim.GIF"), testRecording(), testRenderOptions()); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "anim.GIF")); err != nil {
+		t.Error(err)
+	}
+
+	if err := RenderToFile(filepath.Join(dir, "frame.png"), testRecording(), testRenderOptions()); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "frame_0003.png")); err != nil {
+		t.Error(err)
+	}
+
+	if err := RenderToFile(filepath.Join(dir, "anim.txt"), testRecording(), testRenderOptions()); err == nil {
+		t.Error("expected an error rendering to a .txt file")
+	}
+}
@@ -0,0 +1,54 @@
+package vis
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Runs stty on the terminal attached to stdin
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
+
+// Switches the terminal to deliver each keypress as it's typed, without echoing it or treating
+// ctrl-c as an interrupt, so the player can handle them itself.  Returns a function which restores
+// the previous settings.  This relies on stty, so fails on systems without it or when stdin isn't
+// a terminal.
+func RawInput() (func(), error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := stty("-icanon", "-echo", "-isig", "min", "1"); err != nil {
+		return nil, err
+	}
+
+	return func() { stty(saved) }, nil
+}
+
+// Reads bytes from r into a channel, which is closed once r is exhausted.
+func ReadKeys(r io.Reader) <-chan byte {
+	keys := make(chan byte)
+	go func() {
+		defer close(keys)
+
+		buf := make([]byte, 16)
+		for {
+			n, err := r.Read(buf)
+			for _, b := range buf[:n] {
+				keys <- b
+			}
+			if err != nil {
+				return
+			}
+		}
+	}()
+
+	return keys
+}
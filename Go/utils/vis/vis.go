@@ -0,0 +1,61 @@
+// Frame-by-frame visualisation of grid-based simulations.
+//
+// Solvers which support visualisation take an Observer, which may be nil.  They describe their
+// grid to it once via Begin, then report each step of the simulation as a Frame listing just the
+// cells which changed.  Solvers must check for a nil observer before building any frames, so
+// running without one costs nothing beyond the check.
+package vis
+
+import "aoc/utils"
+
+// How a cell is drawn
+type Style uint8
+
+const (
+	// Drawn as-is
+	Plain Style = iota
+	// Something the simulation has reached or finished with; eg. an energized tile
+	Highlight
+	// Where the simulation is working right now; eg. a search frontier or a moving rock
+	Active
+	// Part of the result; eg. the final path
+	Marked
+)
+
+// The new contents of a single grid cell
+type Cell struct {
+	Pos   utils.Point
+	Char  byte
+	Style Style
+}
+
+// One step of a simulation
+type Frame struct {
+	// Short description of the step, shown alongside it; eg. "tilt north"
+	Label string
+	// Cells which changed since the previous frame
+	Cells []Cell
+}
+
+// Receives the steps of a simulation as it runs.
+type Observer interface {
+	// Called once before any frames with the grid being simulated; every cell starts out Plain.
+	// The observer must not keep the grid, since the solver may modify it afterwards.
+	Begin(grid utils.Grid[byte])
+	// Called for each step of the simulation.
+	Frame(frame Frame)
+}
+
+// An observer which just stores everything it's given, to be played back later.
+type Recording struct {
+	Initial utils.Grid[byte]
+	Frames  []Frame
+}
+
+func (r *Recording) Begin(grid utils.Grid[byte]) {
+	r.Initial = utils.GridFromSlice(append([]byte{}, grid.Slice...), grid.Width())
+}
+
+func (r *Recording) Frame(frame Frame) {
+	r.Frames = append(r.Frames, frame)
+}
@@ -0,0 +1,142 @@
+package vis
+
+import (
+	"aoc/utils"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// A 3x2 grid with a recording of a few changes to it
+func testRecording() *Recording {
+	rec := &Recording{}
+	rec.Begin(utils.GridFromSlice([]byte("ab.c.d"), 3))
+	rec.Frame(Frame{Label: "first", Cells: []Cell{{Pos: utils.Point{X: 1, Y: 0}, Char: 'X', Style: Active}}})
+	rec.Frame(Frame{Label: "empty"})
+	rec.Frame(Frame{Label: "last", Cells: []Cell{
+		{Pos: utils.Point{X: 0, Y: 1}, Char: 'Y', Style: Marked},
+		{Pos: utils.Point{X: 2, Y: 1}, Char: 'Z', Style: Highlight},
+	}})
+
+	return rec
+}
+
+func TestRecording(t *testing.T) {
+	grid := utils.GridFromSlice([]byte("ab.c.d"), 3)
+	rec := &Recording{}
+	rec.Begin(grid)
+
+	// The recording keeps its own copy of the grid
+	grid.Set(utils.Point{X: 0, Y: 0}, 'Q')
+	if got := string(rec.Initial.Slice); got != "ab.c.d" || rec.Initial.Width() != 3 {
+		t.Errorf("got initial grid %q with width %d, want \"ab.c.d\" with width 3", got, rec.Initial.Width())
+	}
+
+	frames := []Frame{{Label: "one"}, {Label: "two", Cells: []Cell{{Char: 'x'}}}}
+	for _, f := range frames {
+		rec.Frame(f)
+	}
+	if !reflect.DeepEqual(rec.Frames, frames) {
+		t.Errorf("got frames %v, want %v", rec.Frames, frames)
+	}
+}
+
+// Plays the recording with the given keypresses (which are closed once sent), failing if it takes
+// too long
+func playWithKeys(t *testing.T, rec *Recording, opts PlayOptions, pressed string) string {
+	t.Helper()
+
+	keys := make(chan byte, len(pressed))
+	for i := 0; i < len(pressed); i++ {
+		keys <- pressed[i]
+	}
+	close(keys)
+
+	var out strings.Builder
+	done := make(chan error)
+	go func() { done <- Play(rec, &out, keys, opts) }()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("player didn't finish")
+	}
+
+	return out.String()
+}
+
+// Gets the status line from the end of the player's output
+func finalStatus(out string) string {
+	status := out[strings.LastIndex(out, "frame "):]
+	return status[:strings.Index(status, "  (")]
+}
+
+func TestPlay(t *testing.T) {
+	out := playWithKeys(t, testRecording(), PlayOptions{FPS: MaxFPS}, "")
+
+	// The initial grid is drawn in full, then each change is drawn in its style
+	for _, want := range []string{
+		"\x1b[?25l\x1b[2J\x1b[Hab.\r\nc.d\r\n",
+		"\x1b[1;2H\x1b[0;1;97;41mX\x1b[0m",
+		"\x1b[2;1H\x1b[0;1;30;42mY\x1b[2;3H\x1b[0;1;33mZ\x1b[0m",
+		"\x1b[4;1H\x1b[2Klast\x1b[5;1H\x1b[2K",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output %q doesn't contain %q", out, want)
+		}
+	}
+
+	if got, want := finalStatus(out), "frame 3/3, 1000 fps, playing"; got != want {
+		t.Errorf("got status %q, want %q", got, want)
+	}
+	if !strings.HasSuffix(out, "\x1b[6;1H\x1b[?25h") {
+		t.Errorf("output %q doesn't end by showing the cursor below the grid", out)
+	}
+}
+
+func TestPlayKeys(t *testing.T) {
+	tests := []struct {
+		opts    PlayOptions
+		pressed string
+		status  string
+	}{
+		// Stepping only works while paused
+		{PlayOptions{FPS: 1, Paused: true}, "nnq", "frame 2/3, 1 fps, paused"},
+		{PlayOptions{FPS: 1, Paused: true}, " n q", "frame 0/3, 1 fps, paused"},
+		{PlayOptions{FPS: 1, Paused: true}, "..\x03", "frame 2/3, 1 fps, paused"},
+		{PlayOptions{FPS: 1, Paused: true}, "nnn", "frame 3/3, 1 fps, paused"},
+		// Speed is doubled and halved, within limits
+		{PlayOptions{FPS: 1, Paused: true}, "++-=q", "frame 0/3, 4 fps, paused"},
+		{PlayOptions{FPS: 0, Paused: true}, "-_q", "frame 0/3, 1 fps, paused"},
+		{PlayOptions{FPS: 5000, Paused: true}, "+q", "frame 0/3, 1000 fps, paused"},
+		{PlayOptions{FPS: 600, Paused: true}, "+q", "frame 0/3, 1000 fps, paused"},
+	}
+
+	for _, test := range tests {
+		out := playWithKeys(t, testRecording(), test.opts, test.pressed)
+		if got := finalStatus(out); got != test.status {
+			t.Errorf("%q: got status %q, want %q", test.pressed, got, test.status)
+		}
+	}
+
+	// Once the keys run out, playback carries on by itself
+	out := playWithKeys(t, testRecording(), PlayOptions{FPS: MaxFPS, Paused: true}, " ")
+	if got, want := finalStatus(out), "frame 3/3, 1000 fps, playing"; got != want {
+		t.Errorf("got status %q, want %q", got, want)
+	}
+}
+
+func TestReadKeys(t *testing.T) {
+	var got []byte
+	for k := range ReadKeys(strings.NewReader("n +q")) {
+		got = append(got, k)
+	}
+
+	if string(got) != "n +q" {
+		t.Errorf("got keys %q, want \"n +q\"", got)
+	}
+}